@@ -0,0 +1,37 @@
+package sudoku
+
+// Arrow is a path of cells whose numbers add up to the number in its circle
+type Arrow struct {
+	// Circle is the index of the cell in Grid.Cells holding the sum
+	Circle int
+	// Cells are the indices of the cells in Grid.Cells along the arrow
+	Cells []int
+}
+
+// Check validates that the numbers along the arrow add up to the circle, see setSum
+func (a *Arrow) Check(g *Grid) error {
+	sum, empty := setSum(g, a.Cells)
+	circle := g.Cells[a.Circle]
+	cells := append([]int{a.Circle}, a.Cells...)
+	switch {
+	case circle == EmptyCell && sum+empty > g.Size:
+		return newErrorConstraint(g, ArrowSum, cells, "numbers already add up to %d", sum)
+	case circle == EmptyCell:
+	case empty == 0 && sum != circle:
+		return newErrorConstraint(g, ArrowSum, cells, "numbers add up to %d instead of %d", sum, circle)
+	case sum+empty > circle:
+		return newErrorConstraint(g, ArrowSum, cells, "numbers already add up to %d of %d", sum, circle)
+	}
+	return nil
+}
+
+// Propagators returns the arrow as sum of its cells minus the circle adding up to 0, see Sum
+func (a *Arrow) Propagators(g *Grid) []Propagator {
+	vars := append(append([]int(nil), a.Cells...), a.Circle)
+	weights := make([]int, len(vars))
+	for j := range weights {
+		weights[j] = 1
+	}
+	weights[len(a.Cells)] = -1
+	return []Propagator{&Sum{Vars: vars, Weights: weights}}
+}
@@ -0,0 +1,73 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArrow_Check(t *testing.T) {
+	arrow := &Arrow{Circle: 0, Cells: []int{1, 2}}
+	tests := []struct {
+		name    string
+		cells   map[int]int
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "sum", cells: map[int]int{0: 7, 1: 3, 2: 4}},
+		{name: "partial sum", cells: map[int]int{0: 7, 1: 3}},
+		{name: "arrow without circle", cells: map[int]int{1: 3, 2: 4}},
+		{name: "wrong sum", cells: map[int]int{0: 8, 1: 3, 2: 4}, wantErr: true},
+		{name: "sum exceeded", cells: map[int]int{0: 4, 1: 4}, wantErr: true},
+		{name: "sum too large for circle", cells: map[int]int{1: 5, 2: 6}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			for i, num := range tt.cells {
+				g.Cells[i] = num
+			}
+			err := arrow.Check(g)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Arrow.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var errConstraint ErrorConstraint
+			if err != nil && (!errors.As(err, &errConstraint) || errConstraint.Type() != ArrowSum ||
+				!reflect.DeepEqual(errConstraint.Cells(), [][2]int{{0, 0}, {1, 0}, {2, 0}})) {
+				t.Errorf("Arrow.Check() error = %v, want error with circle and arrow", err)
+			}
+		})
+	}
+}
+
+func TestArrow_Propagators(t *testing.T) {
+	g := NewGridBoxes(3, 3)
+	cands := make([]Candidates, len(g.Cells))
+	for i := range cands {
+		cands[i] = AllCandidates(9)
+	}
+	cands[0] = between(1, 4)
+	arrow := &Arrow{Circle: 0, Cells: []int{1, 2}}
+	if !propagateConstraint(g, arrow, cands) {
+		t.Fatalf("Arrow.Propagators() failed")
+	}
+	want := []Candidates{between(2, 4), between(1, 3), between(1, 3)}
+	if !reflect.DeepEqual(cands[:3], want) {
+		t.Errorf("Arrow.Propagators() pruned %v, want %v", cands[:3], want)
+	}
+}
+
+func TestSolveGrid_Arrow(t *testing.T) {
+	g, _ := ParseGrid("7......1...3.........8..75.4..53..9...749....3.....5.........41.........576.....8")
+	for _, a := range [][]int{{0, 1, 2}, {28, 29, 30}, {59, 68, 77}, {73, 74, 75}} {
+		g.Constraints = append(g.Constraints, &Arrow{Circle: a[0], Cells: a[1:]})
+	}
+	got, err := SolveGrid(*g, nil)
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	if want := testFieldSolved.Grid(); got.Line() != want.Line() {
+		t.Errorf("SolveGrid() = %s, want %s", got.Line(), want.Line())
+	}
+}
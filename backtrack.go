@@ -0,0 +1,117 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// search is a backtracking search over all solutions of a model
+type search struct {
+	m        *Model
+	ctx      context.Context
+	watchers [][]int
+	// rng shuffles the order in which numbers are tried if set
+	rng   *rand.Rand
+	nodes int
+}
+
+// search calls found for every solution of the model until it returns false, see Model.Search
+func (m *Model) search(ctx context.Context, rng *rand.Rand, found func(values []int) bool) error {
+	m.open()
+	s := &search{m: m, ctx: ctx, watchers: m.watchers(), rng: rng}
+	values := append([]int(nil), m.Values...)
+	domains := append([]Candidates(nil), m.Domains...)
+	all := make([]int, len(m.Propagators))
+	for p := range all {
+		all[p] = p
+	}
+	if !m.propagate(values, domains, s.watchers, all) {
+		return nil
+	}
+	_, err := s.run(values, domains, found)
+	return err
+}
+
+// run calls found for every solution until it returns false
+// It returns false if the search was stopped.
+func (s *search) run(values []int, domains []Candidates, found func(values []int) bool) (bool, error) {
+	s.nodes++
+	if s.nodes%1024 == 0 && s.ctx.Err() != nil {
+		return false, s.ctx.Err()
+	}
+
+	// continue with the open variable with the least possible numbers
+	best := -1
+	for v, d := range domains {
+		if values[v] == EmptyCell && (best == -1 || d.Count() < domains[best].Count()) {
+			best = v
+		}
+	}
+	if best == -1 {
+		return found(append([]int(nil), values...)), nil
+	}
+
+	nums := domains[best].Numbers()
+	if s.rng != nil {
+		s.rng.Shuffle(len(nums), func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
+	}
+	for _, n := range nums {
+		next := append([]int(nil), values...)
+		nextDomains := append([]Candidates(nil), domains...)
+		next[best], nextDomains[best] = n, 0
+		if !s.m.propagate(next, nextDomains, s.watchers, s.watchers[best]) {
+			continue
+		}
+		if cont, err := s.run(next, nextDomains, found); !cont || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// CountSolutions counts all solutions of a field by backtracking
+// The counting stops after limit solutions, a limit smaller than one counts all.
+// Use a limit of 2 to check if a sudoku has a unique solution.
+func CountSolutions(ctx context.Context, f Field, limit int) (int, error) {
+	return CountGridSolutions(ctx, *f.Grid(), limit)
+}
+
+// CountGridSolutions counts all solutions of a grid of any size like CountSolutions
+// All variant constraints of the grid apply, see Grid.Model.
+func CountGridSolutions(ctx context.Context, g Grid, limit int) (int, error) {
+	if err := g.Check(); err != nil {
+		return 0, fmt.Errorf("field is invalid: %w", err)
+	}
+	count := 0
+	err := g.Model().Search(ctx, func([]int) bool {
+		count++
+		return limit < 1 || count < limit
+	})
+	return count, err
+}
+
+// SolveBacktracking finds a solution of any solvable field by backtracking
+// Unlike Solve, it never gets stuck, but cannot explain its steps.
+func SolveBacktracking(ctx context.Context, f Field) (*Field, error) {
+	solved, err := SolveGridModel(ctx, *f.Grid())
+	if err != nil {
+		return nil, err
+	}
+	return solved.Field()
+}
+
+// SolveGridModel finds a solution of any solvable grid by searching its model
+// Unlike SolveGrid, it never gets stuck, but cannot explain its steps.
+func SolveGridModel(ctx context.Context, g Grid) (*Grid, error) {
+	if err := g.Check(); err != nil {
+		return nil, fmt.Errorf("field is invalid: %w", err)
+	}
+	values, err := g.Model().Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	solved := g.Clone()
+	copy(solved.Cells, values)
+	return solved, nil
+}
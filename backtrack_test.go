@@ -0,0 +1,133 @@
+package sudoku
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCountSolutions(t *testing.T) {
+	// generate field with error
+	errorfield := *testField
+	errorfield[0][1] = 7
+
+	tests := []struct {
+		name    string
+		f       Field
+		limit   int
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "unique solution",
+			f:     *testField,
+			limit: 0,
+			want:  1,
+		},
+		{
+			name:  "solved field",
+			f:     *testFieldSolved,
+			limit: 2,
+			want:  1,
+		},
+		{
+			name:  "multiple solutions",
+			f:     *testField2,
+			limit: 10,
+			want:  10,
+		},
+		{
+			name:  "limit",
+			f:     Field{},
+			limit: 5,
+			want:  5,
+		},
+		{
+			name:    "field with errors",
+			f:       errorfield,
+			limit:   0,
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CountSolutions(context.Background(), tt.f, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountSolutions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CountSolutions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountSolutions_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CountSolutions(ctx, Field{}, 0); err != context.Canceled {
+		t.Errorf("CountSolutions() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSolveBacktracking(t *testing.T) {
+	// the last cell of the first row would need a 9, which is already in the column
+	impossible := Field{{1, 2, 3, 4, 5, 6, 7, 8, 0}, 8: {8: 9}}
+
+	tests := []struct {
+		name    string
+		f       Field
+		want    *Field
+		wantErr bool
+	}{
+		{
+			name: "solve field",
+			f:    *testField,
+			want: testFieldSolved,
+		},
+		{
+			name: "no solution",
+			f:    impossible,
+			want: nil, wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SolveBacktracking(context.Background(), tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SolveBacktracking() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SolveBacktracking() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountGridSolutions(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []Constraint
+		want        int
+	}{
+		{name: "all 4x4 grids", want: 288},
+		{name: "thermometer along first row", constraints: []Constraint{&Thermometer{Cells: []int{0, 1, 2, 3}}}, want: 288 / 24},
+		{name: "impossible cage", constraints: []Constraint{&Killer{Cages: []Cage{{Sum: 2, Cells: []int{0, 1}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := NewGrid(4)
+			g.Constraints = tt.constraints
+			got, err := CountGridSolutions(context.Background(), *g, 0)
+			if err != nil {
+				t.Fatalf("CountGridSolutions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CountGridSolutions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
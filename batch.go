@@ -0,0 +1,115 @@
+package sudoku
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// SolveAll solves all fields read from in with a pool of workers
+// If workers is smaller than one, one worker per CPU is started.
+// The results are emitted in the same order as the fields were read.
+// The returned channel is closed after in is closed and all fields are solved
+// or if the context is canceled.
+func SolveAll(ctx context.Context, in <-chan Field, workers int) <-chan Result {
+	grids := make(chan *Grid)
+	go func() {
+		defer close(grids)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case f, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case grids <- f.Grid():
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return SolveAllGrids(ctx, grids, workers)
+}
+
+// SolveAllGrids solves all grids of any size and variant read from in like SolveAll
+func SolveAllGrids(ctx context.Context, in <-chan *Grid, workers int) <-chan Result {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan Result)
+	done := make(chan Result, workers)
+	out := make(chan Result)
+
+	// tag every input grid with its index
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case g, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- Result{Index: i, Puzzle: g}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for r := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				index := r.Index
+				r = SolveGridResult(ctx, *r.Puzzle)
+				r.Index = index
+				select {
+				case done <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// restore input order
+	go func() {
+		defer close(out)
+		pending := make(map[int]Result)
+		next := 0
+		for r := range done {
+			pending[r.Index] = r
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,133 @@
+package sudoku
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSolveAllGrids(t *testing.T) {
+	tests := []struct {
+		name    string
+		grids   []*Grid
+		workers int
+		want    []*Grid
+		wantErr []bool
+	}{
+		{
+			name:    "no fields",
+			grids:   nil,
+			workers: 2,
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:    "keeps input order",
+			grids:   []*Grid{testField.Grid(), NewGridBoxes(3, 3), testField.Grid(), testField2.Grid()},
+			workers: 3,
+			want:    []*Grid{testFieldSolved.Grid(), NewGridBoxes(3, 3), testFieldSolved.Grid(), nil},
+			wantErr: []bool{false, true, false, true},
+		},
+		{
+			name:    "default worker count",
+			grids:   []*Grid{testField.Grid()},
+			workers: 0,
+			want:    []*Grid{testFieldSolved.Grid()},
+			wantErr: []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan *Grid)
+			go func() {
+				for _, g := range tt.grids {
+					in <- g
+				}
+				close(in)
+			}()
+			i := 0
+			for r := range SolveAllGrids(context.Background(), in, tt.workers) {
+				if r.Index != i {
+					t.Errorf("SolveAllGrids() result %d has index %d", i, r.Index)
+				}
+				if !reflect.DeepEqual(r.Puzzle, tt.grids[i]) {
+					t.Errorf("SolveAllGrids() result %d has puzzle %v, want %v", i, r.Puzzle, tt.grids[i])
+				}
+				if (r.Err != nil) != tt.wantErr[i] {
+					t.Errorf("SolveAllGrids() result %d error = %v, wantErr %v", i, r.Err, tt.wantErr[i])
+				}
+				if tt.want[i] != nil && !reflect.DeepEqual(r.Solution, tt.want[i]) {
+					t.Errorf("SolveAllGrids() result %d = %v, want %v", i, r.Solution, tt.want[i])
+				}
+				i++
+			}
+			if i != len(tt.grids) {
+				t.Errorf("SolveAllGrids() returned %d results, want %d", i, len(tt.grids))
+			}
+		})
+	}
+}
+
+func TestSolveAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []Field
+		workers int
+		want    []*Field
+		wantErr []bool
+	}{
+		{
+			name:    "no fields",
+			fields:  nil,
+			workers: 2,
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:    "keeps input order",
+			fields:  []Field{*testField, {}, *testField, *testField2},
+			workers: 3,
+			want:    []*Field{testFieldSolved, {}, testFieldSolved, nil},
+			wantErr: []bool{false, true, false, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan Field)
+			go func() {
+				for _, f := range tt.fields {
+					in <- f
+				}
+				close(in)
+			}()
+			i := 0
+			for r := range SolveAll(context.Background(), in, tt.workers) {
+				if r.Index != i {
+					t.Errorf("SolveAll() result %d has index %d", i, r.Index)
+				}
+				if !reflect.DeepEqual(r.Puzzle, tt.fields[i].Grid()) {
+					t.Errorf("SolveAll() result %d has puzzle %v, want %v", i, r.Puzzle, tt.fields[i])
+				}
+				if (r.Err != nil) != tt.wantErr[i] {
+					t.Errorf("SolveAll() result %d error = %v, wantErr %v", i, r.Err, tt.wantErr[i])
+				}
+				if tt.want[i] != nil && !reflect.DeepEqual(r.Solution, tt.want[i].Grid()) {
+					t.Errorf("SolveAll() result %d = %v, want %v", i, r.Solution, tt.want[i])
+				}
+				i++
+			}
+			if i != len(tt.fields) {
+				t.Errorf("SolveAll() returned %d results, want %d", i, len(tt.fields))
+			}
+		})
+	}
+}
+
+func TestSolveAll_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	in := make(chan Field)
+	for range SolveAll(ctx, in, 2) {
+		t.Errorf("SolveAll() returned result for canceled context")
+	}
+}
@@ -0,0 +1,214 @@
+// Package booklet creates printable PDF booklets of sudokus
+package booklet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// Puzzle is a sudoku in a booklet
+type Puzzle struct {
+	// Grid is the unsolved sudoku of any size
+	Grid *sudoku.Grid
+	// Solution is printed in the appendix, nothing is printed if it is nil
+	Solution *sudoku.Grid
+	// Difficulty is printed next to the title if set
+	Difficulty sudoku.Difficulty
+}
+
+// NewPuzzle solves and rates the field
+// See NewGridPuzzle
+func NewPuzzle(f sudoku.Field) Puzzle {
+	return NewGridPuzzle(f.Grid())
+}
+
+// NewGridPuzzle solves and rates the grid
+// The solution is searched, so it is set for every grid with a unique solution.
+func NewGridPuzzle(g *sudoku.Grid) Puzzle {
+	p := Puzzle{Grid: g}
+	ctx := context.Background()
+	if count, err := sudoku.CountGridSolutions(ctx, *g, 2); err == nil && count == 1 {
+		p.Solution, _ = sudoku.SolveGridModel(ctx, *g)
+	}
+	p.Difficulty, _ = sudoku.RateGrid(*g)
+	return p
+}
+
+// Booklet is the layout of the PDF
+type Booklet struct {
+	// Title is printed at the top of every page
+	Title string
+	// PerPage is the number of puzzles on a page, defaults to 4
+	PerPage int
+	// Solutions adds an appendix with the solutions of all puzzles
+	Solutions bool
+}
+
+// solutionsPerPage is the number of solutions on a page of the appendix
+const solutionsPerPage = 6
+
+// margin around the content of a page in points
+const margin = 50
+
+// Write writes the booklet with all puzzles as PDF
+func (b Booklet) Write(w io.Writer, puzzles []Puzzle) error {
+	perPage := b.PerPage
+	if perPage < 1 {
+		perPage = 4
+	}
+	if len(puzzles) == 0 {
+		return fmt.Errorf("booklet has no puzzles")
+	}
+	if _, err := winAnsi(b.Title); err != nil {
+		return fmt.Errorf("invalid title: %w", err)
+	}
+	for i, puzzle := range puzzles {
+		if err := printable(puzzle.Grid); err != nil {
+			return fmt.Errorf("Sudoku %d: %w", i+1, err)
+		}
+	}
+
+	var doc document
+	number := 0
+	header := func(title string) *page {
+		p := doc.newPage()
+		number++
+		p.text(margin, pageHeight-margin, bold, 18, 0, title)
+		footer := fmt.Sprint(number)
+		p.text((pageWidth-textWidth(footer, 10))/2, margin/2, regular, 10, 0, footer)
+		return p
+	}
+
+	var p *page
+	for i, puzzle := range puzzles {
+		if i%perPage == 0 {
+			p = header(b.Title)
+		}
+		title := fmt.Sprintf("Sudoku %d", i+1)
+		// rules of the whole grid cannot be drawn and are named next to the difficulty
+		var notes []string
+		if puzzle.Difficulty != 0 {
+			notes = append(notes, puzzle.Difficulty.String())
+		}
+		if notes = append(notes, rules(puzzle.Grid)...); len(notes) > 0 {
+			title += fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+		}
+		drawSlot(p, i%perPage, perPage, title, puzzle.Grid, nil)
+	}
+
+	if !b.Solutions {
+		return doc.write(w)
+	}
+	for i, n := 0, 0; i < len(puzzles); i++ {
+		if puzzles[i].Solution == nil {
+			continue
+		}
+		if n%solutionsPerPage == 0 {
+			p = header("Solutions")
+		}
+		drawSlot(p, n%solutionsPerPage, solutionsPerPage, fmt.Sprintf("Sudoku %d", i+1), puzzles[i].Solution, puzzles[i].Grid)
+		n++
+	}
+	return doc.write(w)
+}
+
+// drawSlot draws a titled grid into the i-th of n slots on the page
+func drawSlot(p *page, i, n int, title string, g, initial *sudoku.Grid) {
+	cols := 1
+	if n > 2 {
+		cols = 2
+	}
+	rows := int(math.Ceil(float64(n) / float64(cols)))
+
+	// area below the page title
+	width := float64(pageWidth - 2*margin)
+	height := float64(pageHeight - 2*margin - 30)
+	slotW, slotH := width/float64(cols), height/float64(rows)
+	x := margin + float64(i%cols)*slotW
+	top := pageHeight - margin - 30 - float64(i/cols)*slotH
+
+	titleSize := 12.0
+	size := math.Min(slotW, slotH-2*titleSize) - 20
+	x += (slotW - size) / 2
+	p.text(x, top-titleSize, regular, titleSize, 0, title)
+	drawGrid(p, x, top-2*titleSize-size, size, g, initial)
+}
+
+// drawGrid draws the grid with its lower left corner at (x,y)
+// Numbers which are not part of initial are printed in gray,
+// cells of extra houses like the diagonals of a Sudoku-X are shaded.
+// The constraints are drawn as well, sums outside of the grid take one cell on every side.
+func drawGrid(p *page, x, y, size float64, g, initial *sudoku.Grid) {
+	n := g.Size
+	if outside(g) {
+		border := size / float64(n+2)
+		x, y, size = x+border, y+border, size-2*border
+	}
+	cell := size / float64(n)
+	for _, h := range g.Extra {
+		for _, i := range h.Cells {
+			p.rect(x+float64(i%n)*cell, y+size-float64(i/n+1)*cell, cell, cell, 0.85)
+		}
+	}
+	l := layout{x: x, y: y, size: size, cell: cell, n: n}
+	drawShading(p, l, g)
+	for i := 0; i <= n; i++ {
+		d := float64(i) * cell
+		width := 0.5
+		if i%g.BoxWidth == 0 && g.Regions == nil || i%n == 0 {
+			width = 2
+		}
+		p.line(x+d, y, x+d, y+size, width)
+		width = 0.5
+		if i%g.BoxHeight == 0 && g.Regions == nil || i%n == 0 {
+			width = 2
+		}
+		p.line(x, y+d, x+size, y+d, width)
+	}
+	if g.Regions != nil {
+		drawRegions(p, x, y, size, g)
+	}
+	drawClues(p, l, g)
+
+	fontSize := cell * 0.6
+	line := g.Line()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			num := g.Get(j, i)
+			if num == sudoku.EmptyCell {
+				continue
+			}
+			gray := 0.0
+			if initial != nil && initial.Get(j, i) != num {
+				gray = 0.5
+			}
+			s := line[i*n+j : i*n+j+1]
+			// the baseline is moved down by about a third of the digit height to center it
+			tx := x + float64(j)*cell + (cell-textWidth(s, fontSize))/2
+			ty := y + size - float64(i+1)*cell + (cell-fontSize*0.7)/2
+			p.text(tx, ty, regular, fontSize, gray, s)
+		}
+	}
+}
+
+// drawRegions draws the borders between the regions of a jigsaw sudoku
+func drawRegions(p *page, x, y, size float64, g *sudoku.Grid) {
+	n := g.Size
+	cell := size / float64(n)
+	top := y + size
+	for i, r := range g.Regions {
+		cx, cy := float64(i%n)*cell, float64(i/n)*cell
+		// right and lower border of the cell
+		if i%n < n-1 && g.Regions[i+1] != r {
+			p.line(x+cx+cell, top-cy, x+cx+cell, top-cy-cell, 2)
+		}
+		if i/n < n-1 && g.Regions[i+n] != r {
+			p.line(x+cx, top-cy-cell, x+cx+cell, top-cy-cell, 2)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package booklet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+var testField = sudoku.Field{
+	{7, 0, 0, 0, 0, 4, 8, 0, 0},
+	{0, 0, 0, 0, 0, 5, 4, 0, 0},
+	{0, 0, 9, 0, 0, 0, 7, 0, 0},
+
+	{4, 0, 0, 0, 0, 0, 0, 9, 0},
+	{8, 0, 7, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 6, 1, 0, 0, 0, 0},
+
+	{0, 3, 0, 0, 5, 0, 0, 0, 1},
+	{0, 1, 0, 2, 0, 0, 0, 7, 5},
+	{0, 0, 0, 1, 4, 3, 0, 0, 0},
+}
+
+// checkPDF validates the cross reference table and returns the number of pages
+func checkPDF(t *testing.T, data []byte) int {
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Errorf("PDF has invalid header or trailer")
+	}
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if startxref == nil {
+		t.Fatalf("PDF has no startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(data[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d does not point to object", i+1)
+		}
+	}
+	count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(data)
+	if count == nil {
+		t.Fatalf("PDF has no page count")
+	}
+	n, _ := strconv.Atoi(string(count[1]))
+	return n
+}
+
+func TestBooklet_Write(t *testing.T) {
+	puzzles := make([]Puzzle, 5)
+	for i := range puzzles {
+		puzzles[i] = NewPuzzle(testField)
+	}
+	// puzzles without a unique solution have none in the appendix
+	puzzles = append(puzzles, NewPuzzle(sudoku.Field{}))
+
+	small, _ := sudoku.ParseGrid(".2345.4561.3234.6156.2343.5612.1234.")
+	grids := []Puzzle{NewGridPuzzle(small), NewGridPuzzle(small)}
+
+	tests := []struct {
+		name     string
+		b        Booklet
+		puzzles  []Puzzle
+		pages    int
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name:     "default layout",
+			b:        Booklet{Title: "Lunch (week 1)"},
+			puzzles:  puzzles,
+			pages:    2,
+			contains: []string{`(Lunch \(week 1\)) Tj`, "(Sudoku 1 \\(medium\\)) Tj", "(Sudoku 6 \\(expert\\)) Tj"},
+		},
+		{
+			name:     "with solutions",
+			b:        Booklet{PerPage: 1, Solutions: true},
+			puzzles:  puzzles,
+			pages:    6 + 1,
+			contains: []string{"(Solutions) Tj", "(Sudoku 5) Tj"},
+		},
+		{
+			name:     "6x6 grids",
+			b:        Booklet{Solutions: true},
+			puzzles:  grids,
+			pages:    2,
+			contains: []string{"(Sudoku 2 \\(easy\\)) Tj", "(Sudoku 2) Tj", "(5) Tj"},
+		},
+		{
+			name:     "umlauts",
+			b:        Booklet{Title: "Rätsel für Jörg – Woche 1"},
+			puzzles:  puzzles[:1],
+			pages:    1,
+			contains: []string{"(R\xe4tsel f\xfcr J\xf6rg \x96 Woche 1) Tj"},
+		},
+		{
+			name:    "title not in WinAnsiEncoding",
+			b:       Booklet{Title: "数独"},
+			puzzles: puzzles[:1],
+			wantErr: true,
+		},
+		{
+			name:    "no puzzles",
+			b:       Booklet{},
+			puzzles: nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := tt.b.Write(&b, tt.puzzles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Booklet.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if pages := checkPDF(t, b.Bytes()); pages != tt.pages {
+				t.Errorf("Booklet.Write() has %d pages, want %d", pages, tt.pages)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(b.String(), s) {
+					t.Errorf("Booklet.Write() does not contain %s", s)
+				}
+			}
+		})
+	}
+}
+
+// unknownConstraint is a constraint the booklet cannot draw
+type unknownConstraint struct {
+	*sudoku.Thermometer
+}
+
+func TestBooklet_Write_Constraints(t *testing.T) {
+	solution, err := sudoku.SolveGridModel(context.Background(), *testField.Grid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	killer := testField.Grid()
+	diagonal := sudoku.LittleKillerCells(killer, 9, -1, -1, 1)
+	sum := 0
+	for _, i := range diagonal {
+		sum += solution.Cells[i]
+	}
+	killer.Constraints = []sudoku.Constraint{
+		&sudoku.Killer{Cages: []sudoku.Cage{{Sum: solution.Cells[1] + solution.Cells[2] + solution.Cells[10], Cells: []int{1, 2, 10}}}},
+		&sudoku.LittleKiller{Sum: sum, Cells: diagonal},
+	}
+	knight, _ := sudoku.NewGrid(9)
+	knight.Constraints = []sudoku.Constraint{sudoku.AntiKnight{}}
+	unknown := testField.Grid()
+	unknown.Constraints = []sudoku.Constraint{unknownConstraint{&sudoku.Thermometer{Cells: []int{1, 2}}}}
+
+	tests := []struct {
+		name     string
+		puzzle   Puzzle
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name:     "killer cage and little killer",
+			puzzle:   NewGridPuzzle(killer),
+			contains: []string{"[2.00] 0 d", fmt.Sprintf("(%d) Tj", solution.Cells[1]+solution.Cells[2]+solution.Cells[10]), fmt.Sprintf("(%d) Tj", sum)},
+		},
+		{
+			name:     "rule of the whole grid",
+			puzzle:   Puzzle{Grid: knight},
+			contains: []string{"(Sudoku 1 \\(anti-knight\\)) Tj"},
+		},
+		{
+			name:    "unknown constraint",
+			puzzle:  Puzzle{Grid: unknown},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Booklet{}.Write(&b, []Puzzle{tt.puzzle})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Booklet.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(b.String(), s) {
+					t.Errorf("Booklet.Write() does not contain %s", s)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPuzzle(t *testing.T) {
+	p := NewPuzzle(testField)
+	if p.Solution == nil || p.Solution.EmptyCells() != 0 {
+		t.Errorf("NewPuzzle().Solution = %v, want solved field", p.Solution)
+	}
+	if p.Difficulty != sudoku.Medium {
+		t.Errorf("NewPuzzle().Difficulty = %v, want %v", p.Difficulty, sudoku.Medium)
+	}
+}
+
+func TestNewGridPuzzle(t *testing.T) {
+	g, _ := sudoku.ParseGrid(".2345.4561.3234.6156.2343.5612.1234.")
+	p := NewGridPuzzle(g)
+	if p.Solution == nil || p.Solution.EmptyCells() != 0 {
+		t.Errorf("NewGridPuzzle().Solution = %v, want solved grid", p.Solution)
+	}
+	if p.Difficulty != sudoku.Easy {
+		t.Errorf("NewGridPuzzle().Difficulty = %v, want %v", p.Difficulty, sudoku.Easy)
+	}
+
+	// the solution is searched if the grid cannot be solved with strategies
+	hard, _ := sudoku.ParseGrid("..9748...7.........2.1.9.....7...24..64.1.59..98...3.....8.3.2.........6...2759..")
+	if p := NewGridPuzzle(hard); p.Solution == nil || p.Solution.EmptyCells() != 0 {
+		t.Errorf("NewGridPuzzle().Solution = %v, want solved grid", p.Solution)
+	}
+}
@@ -0,0 +1,276 @@
+package booklet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// layout places the cells of a grid on a page
+type layout struct {
+	// x, y is the lower left corner of the grid
+	x, y, size float64
+	// cell is the width and height of a cell
+	cell float64
+	n    int
+}
+
+// at returns the point of the column and row counted in cells from the upper left corner
+func (l layout) at(col, row float64) [2]float64 {
+	return [2]float64{l.x + col*l.cell, l.y + l.size - row*l.cell}
+}
+
+// center returns the center of the cell i
+func (l layout) center(i int) [2]float64 {
+	return l.at(float64(i%l.n)+0.5, float64(i/l.n)+0.5)
+}
+
+// printable returns an error if the grid has a constraint which cannot be drawn
+func printable(g *sudoku.Grid) error {
+	for _, c := range g.Constraints {
+		switch c.(type) {
+		case *sudoku.Killer, *sudoku.Thermometer, *sudoku.Arrow, *sudoku.Edges, *sudoku.Parity,
+			*sudoku.Sandwich, *sudoku.LittleKiller:
+		case sudoku.AntiKnight, sudoku.AntiKing, sudoku.NonConsecutive:
+		default:
+			return fmt.Errorf("constraint %T cannot be printed", c)
+		}
+	}
+	return nil
+}
+
+// rules returns the names of the constraints which apply to the whole grid
+// They cannot be drawn and are printed next to the title instead.
+func rules(g *sudoku.Grid) []string {
+	var names []string
+	for _, c := range g.Constraints {
+		switch c.(type) {
+		case sudoku.AntiKnight:
+			names = append(names, "anti-knight")
+		case sudoku.AntiKing:
+			names = append(names, "anti-king")
+		case sudoku.NonConsecutive:
+			names = append(names, "non-consecutive")
+		}
+	}
+	return names
+}
+
+// outside returns true if the grid has clues outside of its cells, e.g. sandwich sums
+func outside(g *sudoku.Grid) bool {
+	for _, c := range g.Constraints {
+		switch c.(type) {
+		case *sudoku.Sandwich, *sudoku.LittleKiller:
+			return true
+		}
+	}
+	return false
+}
+
+// drawShading draws the constraints which lie below the grid lines and numbers,
+// gray squares and circles of even and odd cells and thermometers
+func drawShading(p *page, l layout, g *sudoku.Grid) {
+	for _, c := range g.Constraints {
+		switch c := c.(type) {
+		case *sudoku.Parity:
+			for _, i := range c.Even {
+				pt := l.center(i)
+				p.rect(pt[0]-0.35*l.cell, pt[1]-0.35*l.cell, 0.7*l.cell, 0.7*l.cell, 0.85)
+			}
+			for _, i := range c.Odd {
+				pt := l.center(i)
+				p.circle(pt[0], pt[1], 0.38*l.cell, 0, 0.85)
+			}
+		case *sudoku.Thermometer:
+			points := make([][2]float64, len(c.Cells))
+			for j, i := range c.Cells {
+				points[j] = l.center(i)
+			}
+			p.polyline(points, 0.3*l.cell, 0.8, 0)
+			p.circle(points[0][0], points[0][1], 0.38*l.cell, 0, 0.8)
+		}
+	}
+}
+
+// drawClues draws the constraints on top of the grid lines,
+// killer cages, arrows, clues between adjacent cells and sums outside of the grid
+func drawClues(p *page, l layout, g *sudoku.Grid) {
+	for _, c := range g.Constraints {
+		switch c := c.(type) {
+		case *sudoku.Killer:
+			for _, cage := range c.Cages {
+				drawCage(p, l, cage)
+			}
+		case *sudoku.Arrow:
+			drawArrow(p, l, c)
+		case *sudoku.Edges:
+			for _, e := range c.Clues {
+				drawEdge(p, l, e)
+			}
+		case *sudoku.Sandwich:
+			if len(c.Cells) < 2 {
+				continue
+			}
+			// the sum is left of a row or above a column
+			first := c.Cells[0]
+			pt := l.at(-0.5, float64(first/l.n)+0.5)
+			if c.Cells[1]-first != 1 {
+				pt = l.at(float64(first%l.n)+0.5, -0.5)
+			}
+			drawLabel(p, pt, regular, 0.45*l.cell, -1, strconv.Itoa(c.Sum))
+		case *sudoku.LittleKiller:
+			drawLittleKiller(p, l, c)
+		}
+	}
+}
+
+// drawCage draws a dashed line inside the border of the cage and its sum in its first cell
+func drawCage(p *page, l layout, c sudoku.Cage) {
+	if len(c.Cells) == 0 {
+		return
+	}
+	in := make(map[[2]int]bool)
+	first := c.Cells[0]
+	for _, i := range c.Cells {
+		in[[2]int{i % l.n, i / l.n}] = true
+		if i < first {
+			first = i
+		}
+	}
+	// distance of the line from the border
+	const d = 0.1
+	for _, i := range c.Cells {
+		x, y := i%l.n, i/l.n
+		for _, dir := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+			if in[[2]int{x + dir[0], y + dir[1]}] {
+				continue
+			}
+			// the side of the cell towards dir runs along the perpendicular direction
+			px, py := -dir[1], dir[0]
+			cx := float64(x) + 0.5 + float64(dir[0])*(0.5-d)
+			cy := float64(y) + 0.5 + float64(dir[1])*(0.5-d)
+			end := func(s int) [2]float64 {
+				ex, ey := s*px, s*py
+				length := 0.5
+				switch {
+				case !in[[2]int{x + ex, y + ey}]:
+					// the cage turns at a convex corner
+					length -= d
+				case in[[2]int{x + ex + dir[0], y + ey + dir[1]}]:
+					// the cage turns at a concave corner
+					length += d
+				}
+				return l.at(cx+float64(ex)*length, cy+float64(ey)*length)
+			}
+			p.polyline([][2]float64{end(-1), end(1)}, 0.6, 0, 2)
+		}
+	}
+
+	size := 0.22 * l.cell
+	sum := strconv.Itoa(c.Sum)
+	corner := l.at(float64(first%l.n), float64(first/l.n))
+	p.rect(corner[0]+0.03*l.cell, corner[1]-0.06*l.cell-size, textWidth(sum, size)+0.04*l.cell, size+0.04*l.cell, 1)
+	p.text(corner[0]+0.05*l.cell, corner[1]-0.04*l.cell-0.8*size, regular, size, 0, sum)
+}
+
+// drawArrow draws the circle and a line with an arrowhead along the cells
+func drawArrow(p *page, l layout, a *sudoku.Arrow) {
+	r := 0.4 * l.cell
+	circle := l.center(a.Circle)
+	p.circle(circle[0], circle[1], r, 1, -1)
+	if len(a.Cells) == 0 {
+		return
+	}
+	points := [][2]float64{circle}
+	for _, i := range a.Cells {
+		points = append(points, l.center(i))
+	}
+	// the line starts at the circle
+	dx, dy := points[1][0]-circle[0], points[1][1]-circle[1]
+	length := math.Hypot(dx, dy)
+	points[0] = [2]float64{circle[0] + dx/length*r, circle[1] + dy/length*r}
+	p.polyline(points, 1, 0, 0)
+	drawArrowhead(p, points[len(points)-2], points[len(points)-1], 0.25*l.cell)
+}
+
+// drawArrowhead draws the head of an arrow pointing from a to b at b
+func drawArrowhead(p *page, a, b [2]float64, size float64) {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	dx, dy = dx/length*size, dy/length*size
+	// both sides are turned by 30 degrees
+	const sin, cos = 0.5, 0.866
+	left := [2]float64{b[0] - dx*cos + dy*sin, b[1] - dy*cos - dx*sin}
+	right := [2]float64{b[0] - dx*cos - dy*sin, b[1] - dy*cos + dx*sin}
+	p.polyline([][2]float64{left, b, right}, 1, 0, 0)
+}
+
+// drawEdge draws the clue on the middle of the border between both cells
+// Greater-than clues point to the smaller number.
+func drawEdge(p *page, l layout, e sudoku.Edge) {
+	a, b := l.center(e.A), l.center(e.B)
+	m := [2]float64{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+	switch e.Kind {
+	case sudoku.WhiteDot:
+		p.circle(m[0], m[1], 0.12*l.cell, 0.8, 1)
+	case sudoku.BlackDot:
+		p.circle(m[0], m[1], 0.12*l.cell, 0.8, 0)
+	case sudoku.SumX:
+		drawLabel(p, m, bold, 0.3*l.cell, 1, "X")
+	case sudoku.SumV:
+		drawLabel(p, m, bold, 0.3*l.cell, 1, "V")
+	case sudoku.Greater:
+		// unit vectors towards the smaller cell and across the border
+		ux, uy := (b[0]-a[0])/l.cell, (b[1]-a[1])/l.cell
+		vx, vy := -uy, ux
+		s := 0.1 * l.cell
+		p.polyline([][2]float64{
+			{m[0] - ux*s + vx*1.5*s, m[1] - uy*s + vy*1.5*s},
+			{m[0] + ux*s, m[1] + uy*s},
+			{m[0] - ux*s - vx*1.5*s, m[1] - uy*s - vy*1.5*s},
+		}, 1, 0, 0)
+	}
+}
+
+// drawLittleKiller draws the sum outside of the grid where the diagonal starts
+// and a small arrow pointing along the diagonal
+func drawLittleKiller(p *page, l layout, c *sudoku.LittleKiller) {
+	if len(c.Cells) == 0 {
+		return
+	}
+	x, y := c.Cells[0]%l.n, c.Cells[0]/l.n
+	var dx, dy int
+	if len(c.Cells) > 1 {
+		dx, dy = c.Cells[1]%l.n-x, c.Cells[1]/l.n-y
+	} else {
+		// a diagonal of a single cell starts at the corner of the grid
+		dx, dy = 1, 1
+		if x > 0 {
+			dx = -1
+		}
+		if y > 0 {
+			dy = -1
+		}
+	}
+	col, row := float64(x-dx)+0.5, float64(y-dy)+0.5
+	drawLabel(p, l.at(col, row), regular, 0.4*l.cell, -1, strconv.Itoa(c.Sum))
+	from := l.at(col+0.25*float64(dx), row+0.25*float64(dy))
+	to := l.at(col+0.45*float64(dx), row+0.45*float64(dy))
+	p.polyline([][2]float64{from, to}, 0.6, 0, 0)
+	drawArrowhead(p, from, to, 0.1*l.cell)
+}
+
+// drawLabel writes s centered at the point on a background of the brightness gray
+// A negative gray leaves out the background.
+func drawLabel(p *page, pt [2]float64, font string, size, gray float64, s string) {
+	width := textWidth(s, size)
+	if gray >= 0 {
+		p.rect(pt[0]-width/2-0.1*size, pt[1]-0.5*size, width+0.2*size, size, gray)
+	}
+	p.text(pt[0]-width/2, pt[1]-0.35*size, font, size, 0, s)
+}
@@ -0,0 +1,171 @@
+package booklet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// page sizes in points
+const (
+	pageWidth  = 595 // A4
+	pageHeight = 842
+)
+
+// fonts available on every page, they are part of every PDF reader
+const (
+	regular = "F1"
+	bold    = "F2"
+)
+
+// document is a minimal PDF writer for pages with lines and text
+type document struct {
+	pages []*bytes.Buffer
+}
+
+// newPage adds an empty page and returns its content stream
+func (d *document) newPage() *page {
+	p := &page{new(bytes.Buffer)}
+	d.pages = append(d.pages, p.content)
+	return p
+}
+
+// write writes the document as PDF
+func (d *document) write(w io.Writer) error {
+	var b bytes.Buffer
+	var offsets []int
+	object := func(content string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	b.WriteString("%PDF-1.4\n")
+	// object 1 and 2 are the catalog and the page tree,
+	// followed by the fonts and a page and content object for every page
+	const firstPage = 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, regular, bold, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// page is the content stream of a single page
+// The origin is in the lower left corner of the page
+type page struct {
+	content *bytes.Buffer
+}
+
+// line draws a line from (x1,y1) to (x2,y2)
+func (p *page) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// rect fills the rectangle with its lower left corner at (x,y)
+// gray is the brightness of the fill from 0 (black) to 1 (white)
+func (p *page) rect(x, y, w, h, gray float64) {
+	fmt.Fprintf(p.content, "%.2f g %.2f %.2f %.2f %.2f re f\n", gray, x, y, w, h)
+}
+
+// text writes s with its baseline starting at (x,y)
+// gray is the brightness of the text from 0 (black) to 1 (white)
+// Characters missing in WinAnsiEncoding are written as '?', see winAnsi.
+func (p *page) text(x, y float64, font string, size, gray float64, s string) {
+	s, _ = winAnsi(s)
+	s = strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s)
+	fmt.Fprintf(p.content, "BT %.2f g /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", gray, font, size, x, y, s)
+}
+
+// polyline strokes a line through all points with round caps and joins
+// gray is the brightness of the line, dash the length of its dashes or 0 for a solid line
+func (p *page) polyline(points [][2]float64, width, gray, dash float64) {
+	fmt.Fprintf(p.content, "q %.2f G %.2f w 1 J 1 j", gray, width)
+	if dash > 0 {
+		fmt.Fprintf(p.content, " [%.2f] 0 d", dash)
+	}
+	for i, pt := range points {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(p.content, " %.2f %.2f %s", pt[0], pt[1], op)
+	}
+	p.content.WriteString(" S Q\n")
+}
+
+// circle draws a circle around (x,y) out of four Bézier curves
+// It is filled with the brightness fill and stroked with a black line of the given width,
+// a negative fill or a width of 0 leave out the filling or the line.
+func (p *page) circle(x, y, r, width, fill float64) {
+	k := 0.5523 * r
+	fmt.Fprintf(p.content, "q %.2f g %.2f w %.2f %.2f m", math.Max(fill, 0), width, x+r, y)
+	fmt.Fprintf(p.content, " %.2f %.2f %.2f %.2f %.2f %.2f c", x+r, y+k, x+k, y+r, x, y+r)
+	fmt.Fprintf(p.content, " %.2f %.2f %.2f %.2f %.2f %.2f c", x-k, y+r, x-r, y+k, x-r, y)
+	fmt.Fprintf(p.content, " %.2f %.2f %.2f %.2f %.2f %.2f c", x-r, y-k, x-k, y-r, x, y-r)
+	fmt.Fprintf(p.content, " %.2f %.2f %.2f %.2f %.2f %.2f c", x+k, y-r, x+r, y-k, x+r, y)
+	switch {
+	case fill < 0:
+		p.content.WriteString(" S Q\n")
+	case width == 0:
+		p.content.WriteString(" f Q\n")
+	default:
+		p.content.WriteString(" B Q\n")
+	}
+}
+
+// winAnsiSpecial are the characters of WinAnsiEncoding from 0x80 to 0x9f which differ from Latin-1
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// winAnsi transcodes s from UTF-8 to WinAnsiEncoding, the encoding of the fonts
+// Characters missing in the encoding are replaced by '?' and returned as error.
+func winAnsi(s string) (string, error) {
+	b := make([]byte, 0, len(s))
+	var err error
+	for _, r := range s {
+		switch c, ok := winAnsiSpecial[r]; {
+		case ok:
+			b = append(b, c)
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b = append(b, byte(r))
+		default:
+			if err == nil {
+				err = fmt.Errorf("character '%c' cannot be printed", r)
+			}
+			b = append(b, '?')
+		}
+	}
+	return string(b), err
+}
+
+// textWidth estimates the width of s in Helvetica with the given size
+// Digits are exactly 0.556 em wide, the average width is used for everything else
+func textWidth(s string, size float64) float64 {
+	return float64(utf8.RuneCountInString(s)) * 0.556 * size
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// batch solves all sudokus in the files passed as arguments in parallel
+// The solutions are printed in line format or as JSON in the order of the input
+func batch(args []string) {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	workers := flags.Int("workers", runtime.NumCPU(), "Number of sudokus solved in parallel")
+	output := flags.String("format", "line", "Output format: line or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoku batch [flags] file...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	files := flags.Args()
+	if len(files) == 0 || (*output != "line" && *output != "json") {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	// read files in the background so solving can start right away,
+	// a read error stops the input but all sudokus read so far are still solved
+	in := make(chan *sudoku.Grid)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(in)
+		for _, file := range files {
+			grids, err := readGridFile(file)
+			if err != nil {
+				readErr <- err
+				return
+			}
+			for _, g := range grids {
+				in <- g
+			}
+		}
+	}()
+
+	enc := json.NewEncoder(os.Stdout)
+	failed, total := 0, 0
+	for r := range sudoku.SolveAllGrids(context.Background(), in, *workers) {
+		if *output == "json" {
+			enc.Encode(r)
+		} else {
+			fmt.Println(r.Solution.Line())
+		}
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "Sudoku %d: %s\n", r.Index+1, r.Err)
+			failed++
+		}
+		total++
+	}
+	fmt.Fprintf(os.Stderr, "Solved %d of %d sudokus\n", total-failed, total)
+
+	// the input is closed once all results are drained
+	select {
+	case err := <-readErr:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	default:
+	}
+}
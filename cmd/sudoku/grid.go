@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku"
+	"github.com/KeKsBoTer/sudoku/format"
+)
+
+// setRegions turns all grids into jigsaw sudokus with the regions of the region map at path
+func setRegions(grids []*sudoku.Grid, path string) error {
+	regions, err := format.ReadRegionsFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading '%s': %s", path, err)
+	}
+	for _, g := range grids {
+		if len(g.Cells) != len(regions) {
+			return fmt.Errorf("Regions of '%s' do not fit a %dx%d sudoku", path, g.Size, g.Size)
+		}
+		g.Regions = regions
+	}
+	return nil
+}
+
+// solveGattai solves and prints a puzzle of overlapping grids like single grids
+func solveGattai(p *sudoku.Gattai, verbose, debug bool, delay time.Duration) {
+	printed := 0
+	printGattai := func(updated sudoku.Gattai) {
+		Clear(printed)
+		out := updated.PrettyPrint(p)
+		fmt.Println(out)
+		printed = strings.Count(out, "\n")
+	}
+	solved, err := sudoku.SolveGattai(*p, func(updated sudoku.Gattai, s sudoku.Step) {
+		if !verbose {
+			return
+		}
+		printGattai(updated)
+		if debug {
+			fmt.Scanln()
+		} else if delay > 0 {
+			time.Sleep(delay)
+		}
+	})
+	// finish the puzzle by searching its model if no strategy applies
+	if errors.Is(err, sudoku.ErrStuck) {
+		if searched, searchErr := sudoku.SolveGattaiModel(context.Background(), *solved); searchErr == nil {
+			printGattai(*searched)
+			fmt.Println("Solved by search!")
+			return
+		}
+	}
+	printGattai(*solved)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("Solved!")
+	}
+}
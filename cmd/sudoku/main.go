@@ -2,38 +2,144 @@ package main
 
 import (
 	"bufio"
-	"encoding/csv"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/KeKsBoTer/sudoku"
+	"github.com/KeKsBoTer/sudoku/format"
 )
 
+// commands are the subcommands which can be passed as first argument
+var commands = map[string]func(args []string){
+	"batch":  batch,
+	"play":   play,
+	"print":  printBooklet,
+	"serve":  serve,
+	"stream": stream,
+	"web":    web,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd(os.Args[2:])
+			return
+		}
+	}
+
 	verbose := flag.Bool("v", false, "Verbose: prints single steps to console")
 	debug := flag.Bool("d", false, "Debug: Same as verbose, but stops after every step")
 	delay := flag.Int64("delay", 100, "Delay in milliseconds between steps in verbose mode")
-	file := flag.String("file", "sudoku.csv", "Path to the sudoku CSV file")
+	file := flag.String("file", "sudoku.csv", "Path to the sudoku file (.csv, .sdk, .sdm, .ss, .var, .gattai or one sudoku per line)")
+	regions := flag.String("regions", "", "Region map turning all sudokus of -file into jigsaw sudokus")
+	output := flag.String("format", "text", "Output format: text, json, svg, latex, tikz or html")
+	pngDir := flag.String("png", "", "Directory to write a PNG image of every step to")
+	save := flag.String("save", "", "File to save the progress to after every step")
+	resume := flag.String("resume", "", "File with saved progress to continue from, replaces -file")
+	search := flag.Bool("search", false, "Search the solution if the solver gets stuck")
 	flag.Parse()
 
+	switch *output {
+	case "text", "json", "svg", "latex", "tikz", "html":
+	default:
+		fmt.Printf("Unknown output format '%s'\n", *output)
+		return
+	}
 	if *debug {
 		*verbose = true
 	}
 
-	// read csv file
-	csvFile, err := os.Open(*file)
-	if err != nil {
-		fmt.Printf("Error reading '%s': %s", *file, err)
+	// progress of a previous run, which is continued instead of starting from the givens
+	var state *sudoku.State
+	var grids []*sudoku.Grid
+	var err error
+	if *resume != "" {
+		if state, err = sudoku.LoadStateFile(*resume); err != nil {
+			fmt.Printf("Error resuming '%s': %s\n", *resume, err)
+			return
+		}
+		grids = []*sudoku.Grid{state.Givens}
+	} else if filepath.Ext(*file) == format.GattaiExtension {
+		// overlapping grids are only printed as text
+		p, err := format.ReadGattaiFile(*file)
+		if err != nil {
+			fmt.Printf("Error reading '%s': %s\n", *file, err)
+			return
+		}
+		if *output != "text" || *save != "" || *pngDir != "" {
+			fmt.Println("Gattai sudokus only support -format text without -save and -png")
+			return
+		}
+		solveGattai(p, *verbose, *debug, time.Duration(*delay)*time.Millisecond)
 		return
+	} else {
+		if grids, err = readGridFile(*file); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if *regions != "" {
+			if err = setRegions(grids, *regions); err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
 	}
-	field, err := readCSV(bufio.NewReader(csvFile))
-	if err != nil {
-		fmt.Printf("Error parsing file '%s': %s", *file, err)
+	if *save != "" {
+		// the rules of variants like killer cages are not part of the save file
+		for _, g := range grids {
+			if len(g.Constraints) > 0 {
+				fmt.Println("Sudokus with variant constraints do not support -save")
+				return
+			}
+		}
+	}
+
+	switch *output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		for i, g := range grids {
+			r := sudoku.SolveGridResult(context.Background(), *g)
+			r.Index = i
+			enc.Encode(r)
+		}
+		return
+	case "svg":
+		// several sudokus are nested into a single image
+		solved := make([]*sudoku.Grid, len(grids))
+		opts := make([]sudoku.RenderOptions, len(grids))
+		for i, g := range grids {
+			solved[i], _ = sudoku.SolveGrid(*g, nil)
+			opts[i] = sudoku.RenderOptions{Initial: g}
+		}
+		if err := sudoku.RenderSVGs(os.Stdout, solved, opts); err != nil {
+			fmt.Println(err)
+		}
+		return
+	case "latex", "tikz":
+		for _, g := range grids {
+			solved, _ := sudoku.SolveGrid(*g, nil)
+			if *output == "latex" {
+				fmt.Print(solved.LaTeX(g))
+			} else {
+				fmt.Print(solved.TikZ(g))
+			}
+		}
+		return
+	case "html":
+		// several sudokus are tables of a single document
+		tables := make([]string, len(grids))
+		for i, g := range grids {
+			solved, _ := sudoku.SolveGrid(*g, nil)
+			tables[i] = solved.HTMLTable(g)
+		}
+		fmt.Print(sudoku.HTMLDocument(tables...))
 		return
 	}
 
@@ -46,66 +152,95 @@ func main() {
 		}
 	}()
 
-	printed := false
-	printField := func(new sudoku.Field) {
-		if printed {
-			Clear(13)
+	for n, givens := range grids {
+		start := givens
+		var steps []sudoku.Step
+		// a resumed sudoku continues with the saved grid and candidates
+		if state != nil {
+			start, steps = state.Grid, state.Steps
 		}
-		fmt.Println(new.PrettyPrint(field))
-	}
-	solved, err := sudoku.Solve(*field, func(updated sudoku.Field) {
-		if *verbose {
-			printField(updated)
-			printed = true
-			if *debug {
-				fmt.Scanln()
-			} else if *delay > 0 {
-				time.Sleep(time.Duration(*delay) * time.Millisecond)
+		printed := 0
+		printGrid := func(updated sudoku.Grid) {
+			Clear(printed)
+			out := updated.PrettyPrint(givens)
+			fmt.Println(out)
+			printed = strings.Count(out, "\n") + 1
+		}
+		solved, err := sudoku.SolveGrid(*start, func(updated sudoku.Grid, s sudoku.Step) {
+			steps = append(steps, s)
+			if *save != "" {
+				progress := sudoku.NewState(givens, &updated, updated.Candidates(), steps)
+				if err := sudoku.SaveStateFile(*save, progress); err != nil {
+					fmt.Printf("Error saving '%s': %s\n", *save, err)
+				}
+			}
+			if *pngDir != "" {
+				name := filepath.Join(*pngDir, fmt.Sprintf("sudoku%d_step%03d.png", n+1, len(steps)))
+				if err := writePNG(name, &updated, sudoku.RenderOptions{Initial: givens, Step: &s}); err != nil {
+					fmt.Println(err)
+				}
+			}
+			if *verbose {
+				printGrid(updated)
+				if *debug {
+					fmt.Scanln()
+				} else if *delay > 0 {
+					time.Sleep(time.Duration(*delay) * time.Millisecond)
+				}
+			}
+		})
+		// finish the grid by searching its model if no strategy applies
+		if *search && errors.Is(err, sudoku.ErrStuck) {
+			if searched, searchErr := sudoku.SolveGridModel(context.Background(), *solved); searchErr == nil {
+				printGrid(*searched)
+				fmt.Println("Solved by search!")
+				continue
 			}
 		}
-	})
-	printField(*solved)
+		printGrid(*solved)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println("Solved!")
+		}
+	}
+}
+
+// write the grid as PNG image to the file at path
+func writePNG(path string, g *sudoku.Grid, opts sudoku.RenderOptions) error {
+	file, err := os.Create(path)
 	if err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println("Solved!")
+		return fmt.Errorf("Error writing '%s': %s", path, err)
+	}
+	if err := g.RenderPNG(file, opts); err != nil {
+		file.Close()
+		return fmt.Errorf("Error writing '%s': %s", path, err)
 	}
+	return file.Close()
 }
 
-// read sodoku field from csv file
-func readCSV(r io.Reader) (*sudoku.Field, error) {
-	reader := csv.NewReader(r)
-	var field sudoku.Field
-loop:
-	for line := 0; ; line++ {
-		row, err := reader.Read()
-		// error handling
-		switch {
-		case err == io.EOF:
-			if line != 9 {
-				return nil, fmt.Errorf("Too little lines (%d)", line+1)
-			}
-			break loop
-		case err != nil:
-			return nil, err
-		case line > 8:
-			return nil, fmt.Errorf("Too many lines (%d)", line+1)
-		case len(row) != 9:
-			return nil, fmt.Errorf("Line %d has %d cells", line+1, len(row))
-		}
-		for i, v := range row {
-			v = strings.Trim(v, " \r\n\t")
-			if len(v) == 0 {
-				field[line][i] = 0
-				continue
-			}
+// read all sudoku fields from the file at path
+func readFile(path string) ([]sudoku.Field, error) {
+	fields, _, err := format.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading '%s': %s", path, err)
+	}
+	return fields, nil
+}
 
-			num, err := strconv.Atoi(v)
-			if err != nil {
-				return nil, fmt.Errorf("Column %d in line %d is not a number: '%s'", i, line+1, v)
-			}
-			field[line][i] = num
+// read all grids of any size from the file at path
+// Variant descriptions hold a single grid with its rules.
+func readGridFile(path string) ([]*sudoku.Grid, error) {
+	if filepath.Ext(path) == format.VariantExtension {
+		g, err := format.ReadVariantFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading '%s': %s", path, err)
 		}
+		return []*sudoku.Grid{g}, nil
+	}
+	grids, _, err := format.ReadGridFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading '%s': %s", path, err)
 	}
-	return &field, nil
+	return grids, nil
 }
@@ -1,137 +1,92 @@
 package main
 
 import (
-	"io"
+	"os"
+	"path/filepath"
 	"reflect"
-	"strings"
 	"testing"
 
 	"github.com/KeKsBoTer/sudoku"
 )
 
-func Test_readCSV(t *testing.T) {
-	type args struct {
-		r io.Reader
+func Test_readFile(t *testing.T) {
+	field := sudoku.Field{
+		{7, 0, 0, 0, 0, 4, 8, 0, 0},
+		{0, 0, 0, 0, 0, 5, 4, 0, 0},
+		{0, 0, 9, 0, 0, 0, 7, 0, 0},
+		{4, 0, 0, 0, 0, 0, 0, 9, 0},
+		{8, 0, 7, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 6, 1, 0, 0, 0, 0},
+		{0, 3, 0, 0, 5, 0, 0, 0, 1},
+		{0, 1, 0, 2, 0, 0, 0, 7, 5},
+		{0, 0, 0, 1, 4, 3, 0, 0, 0},
 	}
+	dir := t.TempDir()
 	tests := []struct {
 		name    string
-		input   string
-		want    *sudoku.Field
+		file    string
+		content string
+		want    []sudoku.Field
 		wantErr bool
 	}{
 		{
-			name: "valid sudoku file",
-			input: `
-				7,0,0, 0,0,4, 8,0,0
-				0,0,0, 0,0,5, 4,0,0
-				0,0,9, 0,0,0, 7,0,0
-				4,0,0, 0,0,0, 0,9,0
-				8,0,7, 0,0,0, 0,0,0
-				0,0,0, 6,1,0, 0,0,0
-				0,3,0, 0,5,0, 0,0,1
-				0,1,0, 2,0,0, 0,7,5
-				0,0,0, 1,4,3, 0,0,0`,
-			want: &sudoku.Field{
-				{7, 0, 0, 0, 0, 4, 8, 0, 0},
-				{0, 0, 0, 0, 0, 5, 4, 0, 0},
-				{0, 0, 9, 0, 0, 0, 7, 0, 0},
-				{4, 0, 0, 0, 0, 0, 0, 9, 0},
-				{8, 0, 7, 0, 0, 0, 0, 0, 0},
-				{0, 0, 0, 6, 1, 0, 0, 0, 0},
-				{0, 3, 0, 0, 5, 0, 0, 0, 1},
-				{0, 1, 0, 2, 0, 0, 0, 7, 5},
-				{0, 0, 0, 1, 4, 3, 0, 0, 0},
-			},
-			wantErr: false,
-		}, {
-			name: "without zeros",
-			input: `
-				7,,,,,4,8,,
-				,,,,,5,4,,
-				,,9,,,,7,,
-				4,,,,,,,9,
-				8,,7,,,,,,
-				,,,6,1,,,,
-				,3,,,5,,,,1
-				,1,,2,,,,7,5
-				,,,1,4,3,,,`,
-			want: &sudoku.Field{
-				{7, 0, 0, 0, 0, 4, 8, 0, 0},
-				{0, 0, 0, 0, 0, 5, 4, 0, 0},
-				{0, 0, 9, 0, 0, 0, 7, 0, 0},
-				{4, 0, 0, 0, 0, 0, 0, 9, 0},
-				{8, 0, 7, 0, 0, 0, 0, 0, 0},
-				{0, 0, 0, 6, 1, 0, 0, 0, 0},
-				{0, 3, 0, 0, 5, 0, 0, 0, 1},
-				{0, 1, 0, 2, 0, 0, 0, 7, 5},
-				{0, 0, 0, 1, 4, 3, 0, 0, 0},
-			},
+			name:    "csv file",
+			file:    "../../sudoku.csv",
+			want:    []sudoku.Field{field},
 			wantErr: false,
 		},
 		{
-			name: "string in file sudoku file",
-			input: `
-				7,0,0, 0,0,4, 8,0,0
-				0,0,0, 0,"test",5, 4,0,0
-				0,0,9, 0,0,0, 7,0,0
-				4,0,0, 0,0,0, 0,9,0
-				8,0,7, 0,0,0, 0,0,0
-				0,0,0, 6,1,0, 0,0,0
-				0,3,0, 0,5,0, 0,0,1
-				0,1,0, 2,0,0, 0,7,5
-				0,0,0, 1,4,3, 0,0,0`,
-			want:    nil,
-			wantErr: true,
+			name:    "line file",
+			file:    filepath.Join(dir, "puzzles.txt"),
+			content: field.Line() + "\n" + field.Line() + "\n",
+			want:    []sudoku.Field{field, field},
+			wantErr: false,
 		},
 		{
-			name: "to many lines",
-			input: `
-				7,0,0, 0,0,4, 8,0,0
-				0,0,0, 0,0,5, 4,0,0
-				0,0,9, 0,0,0, 7,0,0
-				4,0,0, 0,0,0, 0,9,0
-				8,0,7, 0,0,0, 0,0,0
-				0,0,0, 6,1,0, 0,0,0
-				0,3,0, 0,5,0, 0,0,1
-				0,1,0, 2,0,0, 0,7,5
-				0,1,0, 2,0,0, 0,7,5
-				0,0,0, 1,4,3, 0,0,0`,
-			want:    nil,
-			wantErr: true,
+			name:    "sadman file",
+			file:    "../../format/testdata/sadman.sdk",
+			want:    []sudoku.Field{field},
+			wantErr: false,
 		},
 		{
-			name: "too little lines",
-			input: `
-				7,0,0, 0,0,4, 8,0,0
-				0,0,0, 0,0,5, 4,0,0
-				0,0,9, 0,0,0, 7,0,0
-				4,0,0, 0,0,0, 0,9,0
-				8,0,7, 0,0,0, 0,0,0
-				0,0,0, 6,1,0, 0,0,0
-				0,3,0, 0,5,0, 0,0,1
-				0,0,0, 1,4,3, 0,0,0`,
+			name:    "invalid file",
+			file:    filepath.Join(dir, "invalid.sdk"),
+			content: "7....48..\n",
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "empty file",
-			input: "",
+			name:    "missing file",
+			file:    filepath.Join(dir, "missing.csv"),
 			want:    nil,
 			wantErr: true,
 		},
 	}
-	var r io.Reader
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r = strings.NewReader(tt.input)
-			got, err := readCSV(r)
+			if tt.content != "" {
+				if err := os.WriteFile(tt.file, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := readFile(tt.file)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("readCSV() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("readFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("readCSV() = \n%v,\n want \n%v", got, tt.want)
+				t.Errorf("readFile() = \n%v,\n want \n%v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_readGridFile(t *testing.T) {
+	grids, err := readGridFile("../../format/testdata/sadman.sdk")
+	if err != nil {
+		t.Fatalf("readGridFile() error = %v", err)
+	}
+	if len(grids) != 1 || grids[0].Line() != "7....48.......54....9...7..4......9.8.7.........61.....3..5...1.1.2...75...143..." {
+		t.Errorf("readGridFile() = %v, want the puzzle of the file", grids)
+	}
+}
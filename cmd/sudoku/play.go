@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku"
+	"github.com/fatih/color"
+)
+
+// play lets the user solve a sudoku in the terminal
+func play(args []string) {
+	flags := flag.NewFlagSet("play", flag.ExitOnError)
+	file := flags.String("file", "sudoku.csv", "Path to the sudoku file")
+	index := flags.Int("n", 1, "Number of the sudoku in the file")
+	flags.Parse(args)
+
+	fields, err := readFile(*file)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if *index < 1 || *index > len(fields) {
+		fmt.Printf("File '%s' has %d sudokus\n", *file, len(fields))
+		return
+	}
+
+	restore, err := rawMode()
+	if err != nil {
+		fmt.Printf("Error switching terminal to raw mode: %s\n", err)
+		return
+	}
+	// use the alternate screen and hide the cursor
+	fmt.Print("\033[?1049h\033[?25l")
+	defer func() {
+		fmt.Print("\033[?25h\033[?1049l")
+		restore()
+	}()
+
+	keys := make(chan []byte)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			input := make([]byte, n)
+			copy(input, buf[:n])
+			keys <- input
+		}
+	}()
+
+	u := newUI(fields[*index-1])
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		// raw mode does not return the cursor on new lines
+		fmt.Print("\033[H\033[2J" + strings.Replace(u.render(), "\n", "\r\n", -1))
+		select {
+		case input, ok := <-keys:
+			if !ok {
+				return
+			}
+			for _, key := range parseKeys(input) {
+				if u.handle(key) {
+					return
+				}
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// ui is the state of the terminal user interface
+type ui struct {
+	game    *sudoku.Game
+	x, y    int
+	pencil  bool
+	message string
+	start   time.Time
+	// time needed to solve the sudoku, zero while playing
+	finished time.Duration
+}
+
+func newUI(f sudoku.Field) *ui {
+	return &ui{
+		game:  sudoku.NewGame(f),
+		start: time.Now(),
+	}
+}
+
+// handle reacts on a key press and returns true if the game should end
+func (u *ui) handle(key string) bool {
+	u.message = ""
+	moves := len(u.game.History())
+	var err error
+	switch key {
+	case "q", "\003": // ctrl-c
+		return true
+	case keyUp, "k":
+		u.y = (u.y + 8) % 9
+	case keyDown, "j":
+		u.y = (u.y + 1) % 9
+	case keyLeft, "h":
+		u.x = (u.x + 8) % 9
+	case keyRight, "l":
+		u.x = (u.x + 1) % 9
+	case "m":
+		u.pencil = !u.pencil
+	case "0", " ", "x", "\177": // backspace
+		err = u.game.Clear(u.x, u.y)
+	case "u":
+		if m, ok := u.game.Undo(); ok {
+			u.x, u.y = m.X, m.Y
+		}
+	case "r":
+		if m, ok := u.game.Redo(); ok {
+			u.x, u.y = m.X, m.Y
+		}
+	case "?":
+		u.hint()
+	default:
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			num := int(key[0] - '0')
+			if u.pencil {
+				err = u.game.Toggle(u.x, u.y, num)
+			} else {
+				err = u.game.Set(u.x, u.y, num)
+			}
+		}
+	}
+
+	if err != nil {
+		u.message = color.HiRedString(err.Error())
+		return false
+	}
+	if len(u.game.History()) == moves || u.finished != 0 {
+		return false
+	}
+	field := u.game.Field()
+	if err := field.Check(); err != nil {
+		u.message = color.HiRedString(err.Error())
+	} else if u.game.Solved() {
+		u.finished = time.Since(u.start)
+		u.message = color.HiGreenString("Solved in %s!", formatDuration(u.finished))
+	}
+	return false
+}
+
+// hint moves the cursor to the next cell which can be set and tells why
+func (u *ui) hint() {
+	step, err := sudoku.Hint(u.game.Field())
+	switch {
+	case errors.Is(err, sudoku.ErrStuck):
+		u.message = "No hint available"
+	case err != nil:
+		u.message = color.HiRedString(err.Error())
+	default:
+		u.x, u.y = step.X, step.Y
+		u.message = fmt.Sprintf("Look for a %s", step.Strategy)
+	}
+}
+
+// render draws the field and the status lines
+func (u *ui) render() string {
+	field, givens := u.game.Field(), u.game.Givens()
+	conflicts := field.Conflicts()
+	b := strings.Builder{}
+	b.WriteString(field.PrettyPrintFunc(func(x, y int, num string) string {
+		switch {
+		case conflicts[y][x]:
+			num = color.HiRedString(num)
+		case givens[y][x] != sudoku.EmptyCell:
+			num = color.New(color.Bold).Sprint(num)
+		case field[y][x] != sudoku.EmptyCell:
+			num = color.HiGreenString(num)
+		}
+		if x == u.x && y == u.y {
+			num = color.New(color.ReverseVideo).Sprint(num)
+		}
+		return num
+	}))
+	b.WriteString("\n")
+
+	elapsed := u.finished
+	if elapsed == 0 {
+		elapsed = time.Since(u.start)
+	}
+	mode := "numbers"
+	if u.pencil {
+		mode = "pencil marks"
+	}
+	fmt.Fprintf(&b, "Time %s   Mode: %s\n", formatDuration(elapsed), mode)
+	fmt.Fprintf(&b, "Pencil marks: %v\n", *u.game.Candidates()[u.y][u.x])
+	fmt.Fprintf(&b, "%s\n\n", u.message)
+	b.WriteString("arrows/hjkl move  1-9 enter  0 clear  m pencil marks\n")
+	b.WriteString("u undo  r redo  ? hint  q quit\n")
+	return b.String()
+}
+
+// formatDuration formats a duration as minutes and seconds, e.g. 03:12
+func formatDuration(d time.Duration) string {
+	s := int(d.Seconds())
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
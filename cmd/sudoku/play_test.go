@@ -0,0 +1,164 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+var testField = sudoku.Field{
+	{7, 0, 0, 0, 0, 4, 8, 0, 0},
+	{0, 0, 0, 0, 0, 5, 4, 0, 0},
+	{0, 0, 9, 0, 0, 0, 7, 0, 0},
+	{4, 0, 0, 0, 0, 0, 0, 9, 0},
+	{8, 0, 7, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 6, 1, 0, 0, 0, 0},
+	{0, 3, 0, 0, 5, 0, 0, 0, 1},
+	{0, 1, 0, 2, 0, 0, 0, 7, 5},
+	{0, 0, 0, 1, 4, 3, 0, 0, 0},
+}
+
+func Test_parseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"characters", "1q", []string{"1", "q"}},
+		{"arrows", "\033[A\033[B\033[C\033[D", []string{keyUp, keyDown, keyRight, keyLeft}},
+		{"incomplete escape sequence", "\033[", []string{"\033", "["}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ui_handle(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		x, y    int
+		want    int
+		marks   string
+		message string
+		quit    bool
+	}{
+		{
+			name:    "given cells cannot be changed",
+			keys:    []string{"5"},
+			x:       0,
+			y:       0,
+			want:    7,
+			message: "given cells cannot be changed",
+		},
+		{
+			name: "move and enter",
+			keys: []string{keyRight, "j", "8"},
+			x:    1,
+			y:    1,
+			want: 8,
+		},
+		{
+			name: "wrap around",
+			keys: []string{keyLeft, keyUp, "6"},
+			x:    8,
+			y:    8,
+			want: 6,
+		},
+		{
+			name: "clear",
+			keys: []string{"l", "2", "0"},
+			x:    1,
+			y:    0,
+			want: 0,
+		},
+		{
+			name:  "pencil marks",
+			keys:  []string{"l", "m", "2", "5", "6", "5"},
+			x:     1,
+			y:     0,
+			want:  0,
+			marks: "[2,6]",
+		},
+		{
+			name: "undo and redo",
+			keys: []string{"l", "2", "l", "5", "u", "u", "r"},
+			x:    1,
+			y:    0,
+			want: 2,
+		},
+		{
+			name:    "conflict",
+			keys:    []string{"l", "7"},
+			x:       1,
+			y:       0,
+			want:    7,
+			message: "present more than once",
+		},
+		{
+			name:    "hint",
+			keys:    []string{"?"},
+			x:       1,
+			y:       2,
+			want:    0,
+			message: "hidden single in square",
+		},
+		{
+			name: "quit",
+			keys: []string{"q"},
+			x:    0,
+			y:    0,
+			want: 7,
+			quit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newUI(testField)
+			quit := false
+			for _, key := range tt.keys {
+				quit = u.handle(key)
+			}
+			if quit != tt.quit {
+				t.Errorf("ui.handle() = %v, want %v", quit, tt.quit)
+			}
+			if u.x != tt.x || u.y != tt.y {
+				t.Errorf("ui cursor = (%d,%d), want (%d,%d)", u.x, u.y, tt.x, tt.y)
+			}
+			if got := u.game.Field()[tt.y][tt.x]; got != tt.want {
+				t.Errorf("cell (%d,%d) = %d, want %d", tt.x, tt.y, got, tt.want)
+			}
+			if marks := u.game.Candidates()[tt.y][tt.x]; tt.marks != "" && marks.String() != tt.marks {
+				t.Errorf("pencil marks = %v, want %v", marks, tt.marks)
+			}
+			if !strings.Contains(u.message, tt.message) {
+				t.Errorf("message = %v, want %v", u.message, tt.message)
+			}
+		})
+	}
+}
+
+func Test_ui_solved(t *testing.T) {
+	solved, err := sudoku.Solve(testField, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// leave a single cell empty
+	f := *solved
+	f[0][1] = 0
+	u := newUI(f)
+	u.handle("l")
+	u.handle("2")
+	if u.finished == 0 || !strings.Contains(u.message, "Solved") {
+		t.Errorf("ui.handle() did not finish game, message = %v", u.message)
+	}
+	if !strings.Contains(u.render(), "Solved") {
+		t.Errorf("ui.render() does not show solved message")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/KeKsBoTer/sudoku/booklet"
+)
+
+// printBooklet writes all sudokus in the files passed as arguments to a PDF booklet
+func printBooklet(args []string) {
+	flags := flag.NewFlagSet("print", flag.ExitOnError)
+	out := flags.String("o", "sudoku.pdf", "Path of the PDF file")
+	title := flags.String("title", "Sudoku", "Title printed on every page")
+	perPage := flags.Int("n", 4, "Number of sudokus per page")
+	solutions := flags.Bool("solutions", true, "Add an appendix with all solutions")
+	regions := flags.String("regions", "", "Region map turning all sudokus into jigsaw sudokus")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoku print [flags] file...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 || *perPage < 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var puzzles []booklet.Puzzle
+	for _, file := range flags.Args() {
+		grids, err := readGridFile(file)
+		if err == nil && *regions != "" {
+			err = setRegions(grids, *regions)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, g := range grids {
+			puzzles = append(puzzles, booklet.NewGridPuzzle(g))
+		}
+	}
+
+	b := booklet.Booklet{Title: *title, PerPage: *perPage, Solutions: *solutions}
+	file, err := os.Create(*out)
+	if err == nil {
+		err = b.Write(file, puzzles)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing '%s': %s\n", *out, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Printed %d sudokus to '%s'\n", len(puzzles), *out)
+}
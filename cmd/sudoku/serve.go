@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku/server"
+)
+
+// serve runs the HTTP JSON API until the process is stopped
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "Address to listen on")
+	timeout := flags.Duration("timeout", 10*time.Second, "Maximum time to handle a single request")
+	maxBody := flags.Int64("max-body", 64<<10, "Maximum size of a request body in bytes")
+	maxCount := flags.Int("max-count", 1000, "Maximum number of solutions counted by /count")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoku serve [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: server.New(server.Options{
+			MaxBodySize: *maxBody,
+			Timeout:     *timeout,
+			MaxCount:    *maxCount,
+		}),
+		ReadTimeout: *timeout,
+		// leave some time to write the timeout response
+		WriteTimeout: *timeout + time.Second,
+	}
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
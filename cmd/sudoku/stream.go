@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku/server"
+)
+
+// stream serves the solver steps as server-sent events until the process is stopped
+func stream(args []string) {
+	flags := flag.NewFlagSet("stream", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "Address to listen on")
+	delay := flags.Int64("delay", 100, "Default delay in milliseconds between steps")
+	debug := flags.Bool("d", false, "Debug: wait for /stream/next after every step by default")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoku stream [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 0 || *delay < 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	handler := &server.Stream{
+		Delay: time.Duration(*delay) * time.Millisecond,
+		Step:  *debug,
+	}
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
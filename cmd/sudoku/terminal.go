@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// rawMode switches the terminal into raw mode, so single key presses can be read
+// The returned function restores the previous mode
+func rawMode() (restore func(), err error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() {
+		stty(strings.TrimSpace(state))
+	}, nil
+}
+
+// stty runs the stty command on the terminal connected to stdin
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// keys read from the terminal which are not a single character
+const (
+	keyUp    = "up"
+	keyDown  = "down"
+	keyLeft  = "left"
+	keyRight = "right"
+)
+
+// parseKeys splits the input read from a terminal in raw mode into key presses
+// Arrow keys are returned as keyUp, keyDown, keyLeft and keyRight,
+// everything else as single character.
+func parseKeys(input []byte) []string {
+	arrows := map[byte]string{'A': keyUp, 'B': keyDown, 'C': keyRight, 'D': keyLeft}
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		if input[i] == '\033' && i+2 < len(input) && input[i+1] == '[' {
+			if key, ok := arrows[input[i+2]]; ok {
+				keys = append(keys, key)
+				i += 2
+				continue
+			}
+		}
+		keys = append(keys, string(input[i]))
+	}
+	return keys
+}
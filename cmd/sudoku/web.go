@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku/server"
+)
+
+// web serves the web front-end until the process is stopped
+func web(args []string) {
+	flags := flag.NewFlagSet("web", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "Address to listen on")
+	timeout := flags.Duration("timeout", 10*time.Second, "Maximum time to validate or solve a sudoku")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoku web [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	handler := server.Web(server.Options{Timeout: *timeout}, &server.Stream{Delay: 100 * time.Millisecond})
+	log.Printf("Open http://localhost%s in your browser", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
@@ -0,0 +1,63 @@
+package sudoku
+
+// Constraint is an additional rule of a sudoku variant
+// The cells of a grid are addressed by their index in Grid.Cells.
+type Constraint interface {
+	// Check returns an error if the numbers in the grid violate the rule
+	// Empty cells must not be reported.
+	Check(g *Grid) error
+
+	// Propagators returns the rule as propagators over the cells of the grid, see Grid.Model
+	Propagators(g *Grid) []Propagator
+}
+
+// setSum adds up the numbers of all set cells and counts the empty cells
+// A sum clue is only compared once all of its cells are set, but every empty cell
+// adds at least 1, so the clue is already violated if sum+empty exceeds it.
+// Numbers may repeat within a sum unless the houses forbid it, see Sum.
+func setSum(g *Grid, cells []int) (sum, empty int) {
+	for _, i := range cells {
+		if g.Cells[i] == EmptyCell {
+			empty++
+		}
+		sum += g.Cells[i]
+	}
+	return sum, empty
+}
+
+// value returns the number of a set variable as only candidate
+// or the candidates of an open variable
+func value(values []int, cands []Candidates, i int) Candidates {
+	if num := values[i]; num != EmptyCell {
+		return Candidates(0).With(num)
+	}
+	return cands[i]
+}
+
+// restrict removes all candidates of the open variable i which are not in allowed
+func restrict(values []int, cands []Candidates, i int, allowed Candidates) {
+	if values[i] == EmptyCell {
+		cands[i] &= allowed
+	}
+}
+
+// between returns the set of all numbers from lo to hi
+func between(lo, hi int) Candidates {
+	if lo < 1 {
+		lo = 1
+	}
+	if hi < lo {
+		return 0
+	}
+	return AllCandidates(hi) &^ AllCandidates(lo-1)
+}
+
+// bounds returns the smallest and largest possible number of the variable i
+// Both are 0 if no number is possible.
+func bounds(values []int, cands []Candidates, i int) (lo, hi int) {
+	nums := value(values, cands, i).Numbers()
+	if len(nums) == 0 {
+		return 0, 0
+	}
+	return nums[0], nums[len(nums)-1]
+}
@@ -0,0 +1,55 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testGrid returns an empty 9x9 grid with the given cells set
+func testGrid(cells map[int]int) *Grid {
+	g := NewGridBoxes(3, 3)
+	for i, num := range cells {
+		g.Cells[i] = num
+	}
+	return g
+}
+
+// testCands returns all candidates for the empty cells of the grid and none for the set cells
+func testCands(g *Grid) []Candidates {
+	cands := make([]Candidates, len(g.Cells))
+	for i, num := range g.Cells {
+		if num == EmptyCell {
+			cands[i] = AllCandidates(g.Size)
+		}
+	}
+	return cands
+}
+
+// checkConstraintErr fails the test if err is not an ErrorConstraint of the type with the cells
+// No error is expected if cells is nil.
+func checkConstraintErr(t *testing.T, name string, err error, typ ErrorType, cells [][2]int) {
+	t.Helper()
+	if cells == nil {
+		if err != nil {
+			t.Errorf("%s error = %v, want nil", name, err)
+		}
+		return
+	}
+	var errConstraint ErrorConstraint
+	if !errors.As(err, &errConstraint) || errConstraint.Type() != typ || !reflect.DeepEqual(errConstraint.Cells(), cells) {
+		t.Errorf("%s error = %v, want %s at %v", name, err, typ, cells)
+	}
+}
+
+// checkSolveGrid fails the test if the grid is not solved to the solution
+func checkSolveGrid(t *testing.T, g *Grid, solution *Grid) {
+	t.Helper()
+	got, err := SolveGrid(*g, nil)
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	if got.Line() != solution.Line() {
+		t.Errorf("SolveGrid() = %s, want %s", got.Line(), solution.Line())
+	}
+}
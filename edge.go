@@ -0,0 +1,134 @@
+package sudoku
+
+import "fmt"
+
+// EdgeKind is the type of a clue on the border between two adjacent cells
+type EdgeKind int
+
+const (
+	// WhiteDot means the numbers of both cells are consecutive
+	WhiteDot EdgeKind = iota + 1
+
+	// BlackDot means one number is double the other
+	BlackDot
+
+	// SumX means the numbers add up to 10
+	SumX
+
+	// SumV means the numbers add up to 5
+	SumV
+
+	// Greater means the number of the first cell is greater than the second
+	Greater
+)
+
+// Edge is a clue between the cells A and B
+type Edge struct {
+	Kind EdgeKind
+	// A and B are the indices of the cells in Grid.Cells
+	A, B int
+}
+
+// Edges is the rule of all clues between adjacent cells, e.g. kropki dots
+type Edges struct {
+	Clues []Edge
+}
+
+// edgeKinds describe the relation of the numbers a and b of both cells for every kind of edge
+var edgeKinds = map[EdgeKind]struct {
+	eType  ErrorType
+	reason string
+	holds  func(a, b int) bool
+}{
+	WhiteDot: {Kropki, "%d and %d are not consecutive", func(a, b int) bool { return a-b == 1 || b-a == 1 }},
+	BlackDot: {Kropki, "%d and %d are not double", func(a, b int) bool { return a == 2*b || b == 2*a }},
+	SumX:     {XV, "%d and %d do not add up to 10", func(a, b int) bool { return a+b == 10 }},
+	SumV:     {XV, "%d and %d do not add up to 5", func(a, b int) bool { return a+b == 5 }},
+	Greater:  {GreaterThan, "%d is not greater than %d", func(a, b int) bool { return a > b }},
+}
+
+func (k EdgeKind) String() string {
+	switch k {
+	case WhiteDot:
+		return "white dot"
+	case BlackDot:
+		return "black dot"
+	case SumX:
+		return "X"
+	case SumV:
+		return "V"
+	case Greater:
+		return "greater than"
+	}
+	return fmt.Sprintf("EdgeKind(%d)", int(k))
+}
+
+// Check validates the clues between all pairs of set cells
+func (e *Edges) Check(g *Grid) error {
+	for _, c := range e.Clues {
+		kind, ok := edgeKinds[c.Kind]
+		if !ok {
+			return fmt.Errorf("Unknown clue %s between (%d,%d) and (%d,%d)",
+				c.Kind, c.A%g.Size, c.A/g.Size, c.B%g.Size, c.B/g.Size)
+		}
+		a, b := g.Cells[c.A], g.Cells[c.B]
+		if a != EmptyCell && b != EmptyCell && !kind.holds(a, b) {
+			return newErrorConstraint(g, kind.eType, []int{c.A, c.B}, kind.reason, a, b)
+		}
+	}
+	return nil
+}
+
+// Propagators returns a relation between both cells of every clue
+func (e *Edges) Propagators(g *Grid) []Propagator {
+	var props []Propagator
+	for _, c := range e.Clues {
+		if kind, ok := edgeKinds[c.Kind]; ok {
+			props = append(props, &Relation{A: c.A, B: c.B, Holds: kind.holds})
+		}
+	}
+	return props
+}
+
+// symbols returns the symbol of every clue between two adjacent cells
+// The key holds both cells in reading order, see drawOutline.
+func (e *Edges) symbols(g *Grid) map[[2]int]rune {
+	symbols := make(map[[2]int]rune)
+	for _, c := range e.Clues {
+		first, second := c.A, c.B
+		if first > second {
+			first, second = second, first
+		}
+		horizontal := second-first == 1 && first/g.Size == second/g.Size
+		if !horizontal && second-first != g.Size {
+			continue
+		}
+		var symbol rune
+		switch c.Kind {
+		case WhiteDot:
+			symbol = '○'
+		case BlackDot:
+			symbol = '●'
+		case SumX:
+			symbol = 'X'
+		case SumV:
+			symbol = 'V'
+		case Greater:
+			// the symbol points to the smaller number
+			switch {
+			case horizontal && first == c.A:
+				symbol = '>'
+			case horizontal:
+				symbol = '<'
+			case first == c.A:
+				symbol = 'v'
+			default:
+				symbol = '^'
+			}
+		default:
+			continue
+		}
+		symbols[[2]int{first, second}] = symbol
+	}
+	return symbols
+}
@@ -0,0 +1,161 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// edges returns a clue of the first matching kind between all adjacent cells of a solved grid
+func edges(solved *Grid, kinds ...EdgeKind) *Edges {
+	e := &Edges{}
+	for i := range solved.Cells {
+		for _, j := range []int{i + 1, i + solved.Size} {
+			if j >= len(solved.Cells) || (j == i+1 && j%solved.Size == 0) {
+				continue
+			}
+			for _, k := range kinds {
+				a, b := i, j
+				if k == Greater && solved.Cells[a] < solved.Cells[b] {
+					a, b = b, a
+				}
+				if edgeKinds[k].holds(solved.Cells[a], solved.Cells[b]) {
+					e.Clues = append(e.Clues, Edge{Kind: k, A: a, B: b})
+					break
+				}
+			}
+		}
+	}
+	return e
+}
+
+func TestEdges_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		edge      Edge
+		a, b      int
+		wantType  ErrorType
+		wantCells [][2]int
+	}{
+		{name: "empty", edge: Edge{WhiteDot, 0, 1}},
+		{name: "single", edge: Edge{WhiteDot, 0, 1}, a: 5},
+		{name: "consecutive", edge: Edge{WhiteDot, 0, 1}, a: 5, b: 4},
+		{name: "not consecutive", edge: Edge{WhiteDot, 0, 1}, a: 5, b: 3, wantType: Kropki, wantCells: [][2]int{{0, 0}, {1, 0}}},
+		{name: "double", edge: Edge{BlackDot, 0, 9}, a: 3, b: 6},
+		{name: "not double", edge: Edge{BlackDot, 0, 9}, a: 3, b: 7, wantType: Kropki, wantCells: [][2]int{{0, 0}, {0, 1}}},
+		{name: "ten", edge: Edge{SumX, 0, 1}, a: 3, b: 7},
+		{name: "not ten", edge: Edge{SumX, 0, 1}, a: 3, b: 6, wantType: XV, wantCells: [][2]int{{0, 0}, {1, 0}}},
+		{name: "five", edge: Edge{SumV, 0, 1}, a: 1, b: 4},
+		{name: "not five", edge: Edge{SumV, 0, 1}, a: 1, b: 5, wantType: XV, wantCells: [][2]int{{0, 0}, {1, 0}}},
+		{name: "greater", edge: Edge{Greater, 0, 1}, a: 8, b: 2},
+		{name: "smaller", edge: Edge{Greater, 0, 1}, a: 2, b: 8, wantType: GreaterThan, wantCells: [][2]int{{0, 0}, {1, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			g.Cells[tt.edge.A], g.Cells[tt.edge.B] = tt.a, tt.b
+			err := (&Edges{Clues: []Edge{tt.edge}}).Check(g)
+			if (err != nil) != (tt.wantCells != nil) {
+				t.Errorf("Edges.Check() error = %v, want cells %v", err, tt.wantCells)
+				return
+			}
+			var errConstraint ErrorConstraint
+			if err != nil && (!errors.As(err, &errConstraint) || errConstraint.Type() != tt.wantType ||
+				!reflect.DeepEqual(errConstraint.Cells(), tt.wantCells)) {
+				t.Errorf("Edges.Check() error = %v, want %s at %v", err, tt.wantType, tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestEdges_Propagators(t *testing.T) {
+	tests := []struct {
+		name   string
+		edge   Edge
+		a      int
+		wantA  Candidates
+		wantB  Candidates
+		pruned bool
+	}{
+		{name: "white dot", edge: Edge{WhiteDot, 0, 1}, a: 5, wantB: Candidates(0).With(4).With(6), pruned: true},
+		{name: "black dot", edge: Edge{BlackDot, 0, 1}, wantA: Candidates(0).With(1).With(2).With(3).With(4).With(6).With(8),
+			wantB: Candidates(0).With(1).With(2).With(3).With(4).With(6).With(8), pruned: true},
+		{name: "X", edge: Edge{SumX, 0, 1}, wantA: between(1, 9), wantB: between(1, 9)},
+		{name: "V", edge: Edge{SumV, 0, 1}, a: 1, wantB: Candidates(0).With(4), pruned: true},
+		{name: "greater", edge: Edge{Greater, 0, 1}, wantA: between(2, 9), wantB: between(1, 8), pruned: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			g.Cells[tt.edge.A] = tt.a
+			cands := make([]Candidates, len(g.Cells))
+			for i := range cands {
+				if g.Cells[i] == EmptyCell {
+					cands[i] = AllCandidates(9)
+				}
+			}
+			before := append([]Candidates(nil), cands...)
+			if !propagateConstraint(g, &Edges{Clues: []Edge{tt.edge}}, cands) {
+				t.Fatalf("Edges.Propagators() failed")
+			}
+			if pruned := !reflect.DeepEqual(cands, before); pruned != tt.pruned {
+				t.Errorf("Edges.Propagators() pruned = %v, want %v", pruned, tt.pruned)
+			}
+			if cands[tt.edge.A] != tt.wantA || cands[tt.edge.B] != tt.wantB {
+				t.Errorf("Edges.Propagators() pruned %v %v, want %v %v", cands[tt.edge.A], cands[tt.edge.B], tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestSolveGrid_Edges(t *testing.T) {
+	solved := testFieldSolved.Grid()
+	tests := []struct {
+		name  string
+		grid  string
+		kinds []EdgeKind
+	}{
+		{name: "kropki", grid: "7....................8.....4...........4.....3...................................", kinds: []EdgeKind{WhiteDot, BlackDot}},
+		{name: "XV", grid: "7..........................4............9....3............5......................", kinds: []EdgeKind{SumX, SumV}},
+		{name: "greater than", grid: "...........................4...........49....3.....5............................8", kinds: []EdgeKind{Greater}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := ParseGrid(tt.grid)
+			g.Constraints = []Constraint{edges(solved, tt.kinds...)}
+			got, err := SolveGrid(*g, nil)
+			if err != nil {
+				t.Fatalf("SolveGrid() error = %v", err)
+			}
+			if got.Line() != solved.Line() {
+				t.Errorf("SolveGrid() = %s, want %s", got.Line(), solved.Line())
+			}
+		})
+	}
+}
+
+func TestGrid_PrettyPrint_Edges(t *testing.T) {
+	g, _ := ParseGrid("1...............")
+	g.Constraints = []Constraint{&Edges{Clues: []Edge{
+		{Kind: WhiteDot, A: 0, B: 1},
+		{Kind: BlackDot, A: 1, B: 5},
+		{Kind: SumX, A: 6, B: 7},
+		{Kind: SumV, A: 10, B: 14},
+		{Kind: Greater, A: 3, B: 2},
+		{Kind: Greater, A: 8, B: 12},
+		{Kind: Greater, A: 15, B: 11},
+	}}}
+	want := `
+┏━━━┯━━━┳━━━┯━━━┓
+┃ 1 ○   ┃   <   ┃
+┠───┼─●─╂───┼───┨
+┃   │   ┃   X   ┃
+┣━━━┿━━━╋━━━┿━━━┫
+┃   │   ┃   │   ┃
+┠─v─┼───╂─V─┼─^─┨
+┃   │   ┃   │   ┃
+┗━━━┷━━━┻━━━┷━━━┛`
+	if got := g.PrettyPrint(nil); got != want {
+		t.Errorf("Grid.PrettyPrint() = %v, want %v", got, want)
+	}
+}
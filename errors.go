@@ -2,6 +2,7 @@ package sudoku
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ErrorType is the rule which is violated
@@ -16,6 +17,55 @@ const (
 
 	// Square means a number is represented in a 3x3 square more than once
 	Square = ErrorType("square")
+
+	// Region means a number is represented in a region of a jigsaw sudoku more than once
+	Region = ErrorType("region")
+
+	// Diagonal means a number is represented in a main diagonal of a Sudoku-X more than once
+	Diagonal = ErrorType("diagonal")
+
+	// Window means a number is represented in a window of a Windoku more than once
+	Window = ErrorType("window")
+
+	// KnightMove means a number is represented twice a knight's move apart
+	KnightMove = ErrorType("anti-knight")
+
+	// KingMove means a number is represented twice a king's move apart
+	KingMove = ErrorType("anti-king")
+
+	// Consecutive means orthogonally neighboring cells hold consecutive numbers
+	Consecutive = ErrorType("non-consecutive")
+
+	// ThermoOrder means the numbers on a thermometer do not increase from its bulb
+	ThermoOrder = ErrorType("thermometer")
+
+	// ArrowSum means the numbers on an arrow do not add up to the number in its circle
+	ArrowSum = ErrorType("arrow")
+
+	// SandwichSum means the numbers between 1 and the largest number of a row or column
+	// do not add up to the sandwich clue
+	SandwichSum = ErrorType("sandwich")
+
+	// Kropki means the numbers at a white dot are not consecutive or the numbers at a black dot not double
+	Kropki = ErrorType("kropki")
+
+	// XV means the numbers at an X do not add up to 10 or the numbers at a V not to 5
+	XV = ErrorType("XV")
+
+	// GreaterThan means the numbers at a greater-than sign are in the wrong order
+	GreaterThan = ErrorType("greater than")
+
+	// CellParity means an even number is in an odd cell or an odd number in an even cell
+	CellParity = ErrorType("parity")
+
+	// DiagonalSum means the numbers on the diagonal of a little killer clue do not add up to its sum
+	DiagonalSum = ErrorType("little killer")
+
+	// KillerCage means a number is represented in a killer cage more than once
+	KillerCage = ErrorType("cage")
+
+	// CageSum means the numbers of a killer cage do not add up to its sum
+	CageSum = ErrorType("cage sum")
 )
 
 // ErrorSudoku is a violation of the sukodu rules
@@ -28,3 +78,37 @@ type ErrorSudoku struct {
 func (err ErrorSudoku) Error() string {
 	return fmt.Sprintf("Number %d is present more than once in %s at (%d,%d)", err.num, err.eType, err.x, err.y)
 }
+
+// ErrorConstraint is a violation of the rule of a sudoku variant
+type ErrorConstraint struct {
+	cells  [][2]int
+	eType  ErrorType
+	reason string
+}
+
+// newErrorConstraint creates the error for the cells with the given indices
+func newErrorConstraint(g *Grid, eType ErrorType, cells []int, format string, a ...interface{}) ErrorConstraint {
+	err := ErrorConstraint{eType: eType, reason: fmt.Sprintf(format, a...)}
+	for _, i := range cells {
+		err.cells = append(err.cells, [2]int{i % g.Size, i / g.Size})
+	}
+	return err
+}
+
+func (err ErrorConstraint) Error() string {
+	cells := make([]string, len(err.cells))
+	for i, c := range err.cells {
+		cells[i] = fmt.Sprintf("(%d,%d)", c[0], c[1])
+	}
+	return fmt.Sprintf("Rule of %s is violated at %s: %s", err.eType, strings.Join(cells, " "), err.reason)
+}
+
+// Type returns the violated rule
+func (err ErrorConstraint) Type() ErrorType {
+	return err.eType
+}
+
+// Cells returns the (x,y) coordinates of all cells involved
+func (err ErrorConstraint) Cells() [][2]int {
+	return err.cells
+}
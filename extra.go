@@ -0,0 +1,64 @@
+package sudoku
+
+import "fmt"
+
+// Diagonals returns both main diagonals of a grid of size n, the extra houses of a Sudoku-X
+func Diagonals(n int) []House {
+	main, anti := House{Type: Diagonal}, House{Type: Diagonal}
+	for i := 0; i < n; i++ {
+		main.Cells = append(main.Cells, i*n+i)
+		anti.Cells = append(anti.Cells, i*n+n-1-i)
+	}
+	return []House{main, anti}
+}
+
+// Windows returns the windows of the grid, the extra houses of a Windoku
+// Windows have the size of the boxes and are one cell apart from each other and from the border,
+// e.g. the four windows of a 9x9 grid start at (1,1), (5,1), (1,5) and (5,5).
+func Windows(g *Grid) []House {
+	n := g.Size
+	var windows []House
+	for wy := 1; wy+g.BoxHeight < n; wy += g.BoxHeight + 1 {
+		for wx := 1; wx+g.BoxWidth < n; wx += g.BoxWidth + 1 {
+			window := House{Type: Window}
+			for y := wy; y < wy+g.BoxHeight; y++ {
+				for x := wx; x < wx+g.BoxWidth; x++ {
+					window.Cells = append(window.Cells, y*n+x)
+				}
+			}
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// checkExtra validates that every extra house has Size different cells of the grid
+func (g *Grid) checkExtra() error {
+	for _, h := range g.Extra {
+		if len(h.Cells) != g.Size {
+			return fmt.Errorf("House of type %s has %d instead of %d cells", h.Type, len(h.Cells), g.Size)
+		}
+		seen := make(map[int]bool, len(h.Cells))
+		for _, i := range h.Cells {
+			if i < 0 || i >= len(g.Cells) || seen[i] {
+				return fmt.Errorf("House of type %s has invalid cell %d", h.Type, i)
+			}
+			seen[i] = true
+		}
+	}
+	return nil
+}
+
+// extraCells marks all cells which are part of an extra house
+func (g *Grid) extraCells() []bool {
+	if len(g.Extra) == 0 {
+		return nil
+	}
+	marked := make([]bool, len(g.Cells))
+	for _, h := range g.Extra {
+		for _, i := range h.Cells {
+			marked[i] = true
+		}
+	}
+	return marked
+}
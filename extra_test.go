@@ -0,0 +1,132 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiagonals(t *testing.T) {
+	want := []House{
+		{Type: Diagonal, Cells: []int{0, 5, 10, 15}},
+		{Type: Diagonal, Cells: []int{3, 6, 9, 12}},
+	}
+	if got := Diagonals(4); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagonals() = %v, want %v", got, want)
+	}
+}
+
+func TestWindows(t *testing.T) {
+	windows := Windows(NewGridBoxes(3, 3))
+	if len(windows) != 4 {
+		t.Fatalf("Windows() has %d windows, want 4", len(windows))
+	}
+	want := House{Type: Window, Cells: []int{50, 51, 52, 59, 60, 61, 68, 69, 70}}
+	if !reflect.DeepEqual(windows[3], want) {
+		t.Errorf("Windows()[3] = %v, want %v", windows[3], want)
+	}
+	if got := len(Windows(NewGridBoxes(4, 4))); got != 9 {
+		t.Errorf("Windows() of 16x16 grid has %d windows, want 9", got)
+	}
+}
+
+func TestGrid_Check_Extra(t *testing.T) {
+	// a valid sudoku with 1 and 4 repeated on the diagonals
+	valid, _ := ParseGrid("1234341221434321")
+	diagonal := valid.Clone()
+	diagonal.Extra = Diagonals(4)
+
+	malformed := valid.Clone()
+	malformed.Extra = []House{{Type: Diagonal, Cells: []int{0, 5, 10}}}
+
+	tests := []struct {
+		name     string
+		g        *Grid
+		wantType ErrorType
+		wantErr  bool
+	}{
+		{name: "no extra houses", g: valid},
+		{name: "diagonal", g: diagonal, wantType: Diagonal, wantErr: true},
+		{name: "malformed house", g: malformed, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.g.Check()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Grid.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var errSudoku ErrorSudoku
+			if tt.wantType != "" && (!errors.As(err, &errSudoku) || errSudoku.eType != tt.wantType) {
+				t.Errorf("Grid.Check() error = %v, want error in %s", err, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSolveGrid_Extra(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		extra    func(g *Grid) []House
+		want     string
+		strategy Strategy
+	}{
+		{
+			name:     "Sudoku-X",
+			line:     "4.285...........39..........6..................8.2.3.....1.2.98..143.7....9.8....",
+			extra:    func(g *Grid) []House { return Diagonals(g.Size) },
+			want:     "492853671185267439376914285563748912217395864948621357634172598851439726729586143",
+			strategy: HiddenSingleDiagonal,
+		},
+		{
+			name:     "Windoku",
+			line:     "...........5..74.....9...............64.3.1..2..1...8..296................3...5..",
+			extra:    Windows,
+			want:     "492853671185267439376941852831472965964538127257196384529684713748315296613729548",
+			strategy: HiddenSingleWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := ParseGrid(tt.line)
+			if _, err := SolveGrid(*g, nil); err == nil {
+				t.Errorf("SolveGrid() solved the puzzle without extra houses")
+			}
+			g.Extra = tt.extra(g)
+			found := false
+			got, err := SolveGrid(*g, func(updated Grid, s Step) {
+				if s.Strategy == tt.strategy {
+					found = true
+				}
+			})
+			if err != nil {
+				t.Fatalf("SolveGrid() error = %v", err)
+			}
+			if got.Line() != tt.want {
+				t.Errorf("SolveGrid() = %s, want %s", got.Line(), tt.want)
+			}
+			if !found {
+				t.Errorf("SolveGrid() did not use %s", tt.strategy)
+			}
+		})
+	}
+}
+
+func TestGrid_PrettyPrint_Extra(t *testing.T) {
+	g, _ := ParseGrid("1..2.3.....1.4..")
+	g.Extra = Diagonals(4)
+	want := `
+┏━━━┯━━━┳━━━┯━━━┓
+┃[1]│   ┃   │[2]┃
+┠───┼───╂───┼───┨
+┃   │[3]┃[ ]│   ┃
+┣━━━┿━━━╋━━━┿━━━┫
+┃   │[ ]┃[ ]│ 1 ┃
+┠───┼───╂───┼───┨
+┃[ ]│ 4 ┃   │[ ]┃
+┗━━━┷━━━┻━━━┷━━━┛`
+	if got := g.PrettyPrint(nil); got != want {
+		t.Errorf("Grid.PrettyPrint() = %v, want %v", got, want)
+	}
+}
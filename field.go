@@ -1,9 +1,6 @@
 package sudoku
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/fatih/color"
 )
 
@@ -130,35 +127,30 @@ func (f Field) String() string {
 // ║ │7│ ║1│4│3║ │ │8║
 // ╚═════╩═════╩═════╝
 func (f Field) PrettyPrint(initial *Field) string {
-	b := strings.Builder{}
-	var num string
-	b.WriteString("\n╔═════╦═════╦═════╗\n")
-	for i := 0; i < 9; i++ {
-		b.WriteString("║")
-		for j := 0; j < 9; j++ {
-			if f[i][j] == 0 {
-				b.WriteString(" ")
-			} else {
-				num = strconv.Itoa(f[i][j])
-				if initial != nil && f[i][j] != initial[i][j] {
-					num = color.HiGreenString(num)
-				}
-				b.WriteString(num)
-			}
-			if j < 8 {
-				if (j+1)%3 == 0 {
-					b.WriteString("║")
-				} else {
-					b.WriteString("│")
-				}
-			}
-		}
-		b.WriteString("║")
-		b.WriteString("\n")
-		if (i+1)%3 == 0 && i != 8 {
-			b.WriteString("╠═════╬═════╬═════╣\n")
+	return f.PrettyPrintFunc(func(x, y int, num string) string {
+		if initial != nil && f[y][x] != EmptyCell && f[y][x] != initial[y][x] {
+			return color.HiGreenString(num)
 		}
+		return num
+	})
+}
+
+// CellFormatter decorates the printed number of the cell at (x,y)
+// Empty cells are passed as a single space
+type CellFormatter func(x, y int, num string) string
+
+// PrettyPrintFunc prints the field in the same layout as PrettyPrint,
+// but every cell is passed to format before it is printed
+// This allows to highlight cells, e.g. with colors.
+func (f Field) PrettyPrintFunc(format CellFormatter) string {
+	return f.Grid().PrettyPrintFunc(format)
+}
+
+// Conflicts marks all cells whose number is present more than once
+// in their row, column or 3x3 square, which are the cells Check complains about
+func (f *Field) Conflicts() (marked [9][9]bool) {
+	for i, c := range f.Grid().Conflicts() {
+		marked[i/9][i%9] = c
 	}
-	b.WriteString("╚═════╩═════╩═════╝")
-	return b.String()
+	return marked
 }
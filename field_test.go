@@ -1,6 +1,8 @@
 package sudoku
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -63,3 +65,80 @@ func TestField_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestField_PrettyPrintFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      Field
+		format CellFormatter
+		want   string
+	}{
+		{
+			name:   "without formatter",
+			f:      *testField,
+			format: nil,
+			want:   "║7│ │ ║ │ │4║8│ │ ║",
+		},
+		{
+			name: "mark empty cells",
+			f:    *testField,
+			format: func(x, y int, num string) string {
+				if num == " " {
+					return "."
+				}
+				return num
+			},
+			want: "║7│.│.║.│.│4║8│.│.║",
+		},
+		{
+			name: "cell coordinates",
+			f:    Field{},
+			format: func(x, y int, num string) string {
+				return strconv.Itoa(x)
+			},
+			want: "║0│1│2║3│4│5║6│7│8║",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(tt.f.PrettyPrintFunc(tt.format), "\n")
+			if len(got) != 14 || got[2] != tt.want {
+				t.Errorf("Field.PrettyPrintFunc() = %v, want first row %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_Conflicts(t *testing.T) {
+	// generate field with error
+	errorfield := *testField
+	errorfield[0][1] = 7
+
+	tests := []struct {
+		name string
+		f    *Field
+		want [][2]int
+	}{
+		{
+			name: "no conflicts",
+			f:    testField,
+			want: nil,
+		},
+		{
+			name: "conflict in row and square",
+			f:    &errorfield,
+			want: [][2]int{{0, 0}, {1, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want [9][9]bool
+			for _, c := range tt.want {
+				want[c[1]][c[0]] = true
+			}
+			if got := tt.f.Conflicts(); got != want {
+				t.Errorf("Field.Conflicts() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// ReadCSV reads a sudoku field from csv
+// Every line holds a row, empty cells are either '0' or blank
+func ReadCSV(r io.Reader) (*sudoku.Field, error) {
+	reader := csv.NewReader(r)
+	var field sudoku.Field
+loop:
+	for line := 0; ; line++ {
+		row, err := reader.Read()
+		// error handling
+		switch {
+		case err == io.EOF:
+			if line != 9 {
+				return nil, fmt.Errorf("Too little lines (%d)", line+1)
+			}
+			break loop
+		case err != nil:
+			return nil, err
+		case line > 8:
+			return nil, fmt.Errorf("Too many lines (%d)", line+1)
+		case len(row) != 9:
+			return nil, fmt.Errorf("Line %d has %d cells", line+1, len(row))
+		}
+		for i, v := range row {
+			v = strings.Trim(v, " \r\n\t")
+			if len(v) == 0 {
+				field[line][i] = 0
+				continue
+			}
+
+			num, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("Column %d in line %d is not a number: '%s'", i, line+1, v)
+			}
+			field[line][i] = num
+		}
+	}
+	return &field, nil
+}
@@ -0,0 +1,148 @@
+// Package format reads and writes sudoku fields in common file formats
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// Format is a file format for sudoku fields
+type Format int
+
+const (
+	// Line is one field per line as 81 characters with '.' for empty cells
+	Line Format = iota
+
+	// CSV is a single field with one row per line and comma separated cells
+	CSV
+
+	// SDK is the SadMan Sudoku format, a single field with one row per line
+	SDK
+
+	// SDM is the SadMan Sudoku multi-puzzle format, one field per line with '0' for empty cells
+	SDM
+
+	// SS is the Simple Sudoku format, a single field with '|' and '-' box separators
+	SS
+)
+
+// extensions maps file extensions to their format
+var extensions = map[string]Format{
+	".txt": Line,
+	".csv": CSV,
+	".sdk": SDK,
+	".sdm": SDM,
+	".ss":  SS,
+}
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case Line:
+		return "line"
+	case CSV:
+		return "csv"
+	case SDK:
+		return "sdk"
+	case SDM:
+		return "sdm"
+	case SS:
+		return "ss"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Read reads all fields from r
+// The fields are read like grids, see ReadGrids, but must be classic 9x9 sudokus.
+func (f Format) Read(r io.Reader) ([]sudoku.Field, error) {
+	grids, err := f.ReadGrids(r)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]sudoku.Field, len(grids))
+	for i, g := range grids {
+		field, err := g.Field()
+		if err != nil {
+			return nil, fmt.Errorf("Sudoku %d: %s", i+1, err)
+		}
+		fields[i] = *field
+	}
+	return fields, nil
+}
+
+// Write writes all fields to w like WriteGrids
+func (f Format) Write(w io.Writer, fields ...sudoku.Field) error {
+	grids := make([]*sudoku.Grid, len(fields))
+	for i, field := range fields {
+		grids[i] = field.Grid()
+	}
+	return f.WriteGrids(w, grids...)
+}
+
+// ByExtension returns the format for the file extension of path
+func ByExtension(path string) (Format, bool) {
+	f, ok := extensions[strings.ToLower(filepath.Ext(path))]
+	return f, ok
+}
+
+// Detect guesses the format from the content of a file
+func Detect(data []byte) Format {
+	if bytes.ContainsAny(data, "|+") {
+		return SS
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		switch {
+		case strings.Contains(line, ","):
+			return CSV
+		case len(strings.Fields(line)[0]) >= sudoku.LineLength:
+			if strings.Contains(line, ".") {
+				return Line
+			}
+			return SDM
+		default:
+			return SDK
+		}
+	}
+	return Line
+}
+
+// ReadFile reads all fields from the file at path
+// The format is chosen by the file extension
+// or detected from the content if the extension is unknown
+func ReadFile(path string) ([]sudoku.Field, Format, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, ok := ByExtension(path)
+	if !ok {
+		f = Detect(data)
+	}
+	fields, err := f.Read(bytes.NewReader(data))
+	return fields, f, err
+}
+
+// WriteFile writes all fields to the file at path
+// The format is chosen by the file extension, unknown extensions use Line
+func WriteFile(path string, fields ...sudoku.Field) error {
+	f, _ := ByExtension(path)
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Write(file, fields...); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,319 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+var testField = sudoku.Field{
+	{7, 0, 0, 0, 0, 4, 8, 0, 0},
+	{0, 0, 0, 0, 0, 5, 4, 0, 0},
+	{0, 0, 9, 0, 0, 0, 7, 0, 0},
+
+	{4, 0, 0, 0, 0, 0, 0, 9, 0},
+	{8, 0, 7, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 6, 1, 0, 0, 0, 0},
+
+	{0, 3, 0, 0, 5, 0, 0, 0, 1},
+	{0, 1, 0, 2, 0, 0, 0, 7, 5},
+	{0, 0, 0, 1, 4, 3, 0, 0, 0},
+}
+
+const (
+	testLine = "7....48.......54....9...7..4......9.8.7.........61.....3..5...1.1.2...75...143...\n"
+	testSDM  = "700004800000005400009000700400000090807000000000610000030050001010200075000143000\n"
+	testSDK  = `7....48..
+.....54..
+..9...7..
+4......9.
+8.7......
+...61....
+.3..5...1
+.1.2...75
+...143...
+`
+	testSS = `*-----------*
+|7..|..4|8..|
+|...|..5|4..|
+|..9|...|7..|
+|---+---+---|
+|4..|...|.9.|
+|8.7|...|...|
+|...|61.|...|
+|---+---+---|
+|.3.|.5.|..1|
+|.1.|2..|.75|
+|...|143|...|
+*-----------*
+`
+)
+
+func TestFormat_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       Format
+		input   string
+		want    []sudoku.Field
+		wantErr bool
+	}{
+		{
+			name:    "line",
+			f:       Line,
+			input:   "# collection\n" + testLine + "\n" + testSDM,
+			want:    []sudoku.Field{testField, testField},
+			wantErr: false,
+		},
+		{
+			name:    "invalid line",
+			f:       Line,
+			input:   testLine[3:],
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty line file",
+			f:       Line,
+			input:   "",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "sdm",
+			f:       SDM,
+			input:   testSDM + testLine,
+			want:    []sudoku.Field{testField, testField},
+			wantErr: false,
+		},
+		{
+			name:    "sdk with comments",
+			f:       SDK,
+			input:   "#Aauthor\n#Ddescription\n" + testSDK,
+			want:    []sudoku.Field{testField},
+			wantErr: false,
+		},
+		{
+			name:    "sdk with sections",
+			f:       SDK,
+			input:   "[Puzzle]\n#Aauthor\n" + testSDK + "[State]\n" + testSDK,
+			want:    []sudoku.Field{testField},
+			wantErr: false,
+		},
+		{
+			name:    "sdk with missing cells",
+			f:       SDK,
+			input:   testSDK[:20],
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "ss",
+			f:       SS,
+			input:   testSS,
+			want:    []sudoku.Field{testField},
+			wantErr: false,
+		},
+		{
+			name: "ss without borders",
+			f:    SS,
+			input: `7..|..4|8..
+...|..5|4..
+..9|...|7..
+-----------
+4..|...|.9.
+8.7|...|...
+...|61.|...
+-----------
+.3.|.5.|..1
+.1.|2..|.75
+...|143|...
+`,
+			want:    []sudoku.Field{testField},
+			wantErr: false,
+		},
+		{
+			name:    "ss with invalid character",
+			f:       SS,
+			input:   strings.Replace(testSS, "7", "a", 1),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "csv",
+			f:    CSV,
+			input: `7,0,0,0,0,4,8,0,0
+				0,0,0,0,0,5,4,0,0
+				0,0,9,0,0,0,7,0,0
+				4,0,0,0,0,0,0,9,0
+				8,0,7,0,0,0,0,0,0
+				0,0,0,6,1,0,0,0,0
+				0,3,0,0,5,0,0,0,1
+				0,1,0,2,0,0,0,7,5
+				0,0,0,1,4,3,0,0,0`,
+			want:    []sudoku.Field{testField},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.Read(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Format.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Format.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      Format
+		fields []sudoku.Field
+		want   string
+	}{
+		{
+			name:   "line",
+			f:      Line,
+			fields: []sudoku.Field{testField, testField},
+			want:   testLine + testLine,
+		},
+		{
+			name:   "sdm",
+			f:      SDM,
+			fields: []sudoku.Field{testField},
+			want:   testSDM,
+		},
+		{
+			name:   "sdk",
+			f:      SDK,
+			fields: []sudoku.Field{testField},
+			want:   testSDK,
+		},
+		{
+			name:   "multiple ss",
+			f:      SS,
+			fields: []sudoku.Field{testField, testField},
+			want:   testSS + "\n" + testSS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.f.Write(&b, tt.fields...); err != nil {
+				t.Errorf("Format.Write() error = %v", err)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Format.Write() = \n%v, want \n%v", got, tt.want)
+			}
+			// everything written must be readable again
+			read, err := tt.f.Read(&b)
+			if err != nil {
+				t.Errorf("Format.Read() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(read, tt.fields) {
+				t.Errorf("Format.Read() = %v, want %v", read, tt.fields)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{"line", testLine, Line},
+		{"sdm", testSDM, SDM},
+		{"sdk", "#Aauthor\n" + testSDK, SDK},
+		{"ss", testSS, SS},
+		{"csv", "7,0,0, 0,0,4, 8,0,0\n", CSV},
+		{"empty", "", Line},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect([]byte(tt.data)); got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByExtension(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   Format
+		wantOk bool
+	}{
+		{"puzzles/a.sdk", SDK, true},
+		{"a.SDM", SDM, true},
+		{"a.ss", SS, true},
+		{"sudoku.csv", CSV, true},
+		{"a.txt", Line, true},
+		{"a.unknown", Line, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := ByExtension(tt.path)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ByExtension() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		path string
+		want Format
+	}{
+		{"by extension", filepath.Join(dir, "a.ss"), SS},
+		{"by content", filepath.Join(dir, "puzzle"), SDK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			tt.want.Write(&b, testField)
+			if err := os.WriteFile(tt.path, b.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			fields, f, err := ReadFile(tt.path)
+			if err != nil {
+				t.Errorf("ReadFile() error = %v", err)
+				return
+			}
+			if f != tt.want {
+				t.Errorf("ReadFile() format = %v, want %v", f, tt.want)
+			}
+			if !reflect.DeepEqual(fields, []sudoku.Field{testField}) {
+				t.Errorf("ReadFile() = %v, want %v", fields, testField)
+			}
+		})
+	}
+}
+
+func TestReadFile_SadMan(t *testing.T) {
+	fields, f, err := ReadFile(filepath.Join("testdata", "sadman.sdk"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if f != SDK {
+		t.Errorf("ReadFile() format = %v, want %v", f, SDK)
+	}
+	if !reflect.DeepEqual(fields, []sudoku.Field{testField}) {
+		t.Errorf("ReadFile() = %v, want %v", fields, testField)
+	}
+}
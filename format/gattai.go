@@ -0,0 +1,159 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// GattaiExtension is the file extension of gattai puzzles
+const GattaiExtension = ".gattai"
+
+// ReadGattai reads a puzzle of overlapping grids
+// The layout is given first, either by the line samurai or by a line per grid
+// with the position of its top left cell and an optional size, which is 9 by default.
+// All following lines are the rows of the layout with one character per cell like in Line,
+// positions without a cell are spaces. Lines starting with '#' are skipped.
+// e.g. a samurai sudoku:
+// samurai
+// ..6.9....   ..8..21..
+// 3..8.7...   .9....6.2
+// ...
+func ReadGattai(r io.Reader) (*sudoku.Gattai, error) {
+	p := &sudoku.Gattai{}
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		words := strings.Fields(text)
+		if len(words) > 0 && strings.HasPrefix(words[0], "#") {
+			continue
+		}
+		switch {
+		case len(words) == 1 && strings.ToLower(words[0]) == "samurai" && rows == nil:
+			p.Parts = append(p.Parts, sudoku.Samurai().Parts...)
+		case len(words) > 0 && strings.ToLower(words[0]) == "grid" && rows == nil:
+			part, err := readPart(words[1:])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", line, err)
+			}
+			p.Parts = append(p.Parts, part)
+		case len(p.Parts) == 0 && len(words) == 0:
+		case len(p.Parts) == 0:
+			return nil, fmt.Errorf("Line %d: layout must be given in front of the rows", line)
+		default:
+			rows = append(rows, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Parts) == 0 {
+		return nil, fmt.Errorf("No sudoku found")
+	}
+
+	width, height := p.Size()
+	if len(rows) != height {
+		return nil, fmt.Errorf("Layout has %d instead of %d rows", len(rows), height)
+	}
+	for y, row := range rows {
+		cells := []rune(strings.ToUpper(row))
+		if len(cells) > width {
+			return nil, fmt.Errorf("Row %d has %d instead of %d cells", y+1, len(cells), width)
+		}
+		for x := 0; x < width; x++ {
+			c := ' '
+			if x < len(cells) {
+				c = cells[x]
+			}
+			_, ok := p.Get(x, y)
+			switch {
+			case !ok && c != ' ':
+				return nil, fmt.Errorf("Row %d has a number outside of the grids at column %d", y+1, x+1)
+			case !ok:
+			case c == ' ':
+				return nil, fmt.Errorf("Row %d has no cell at column %d", y+1, x+1)
+			default:
+				num, err := sudoku.ParseDigit(c, gridSize(p, x, y))
+				if err != nil {
+					return nil, fmt.Errorf("Row %d: %s", y+1, err)
+				}
+				p.Set(x, y, num)
+			}
+		}
+	}
+	return p, nil
+}
+
+// ReadGattaiFile reads the puzzle from the file at path
+func ReadGattaiFile(path string) (*sudoku.Gattai, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadGattai(file)
+}
+
+// WriteGattai writes the puzzle with a line per grid, see ReadGattai
+func WriteGattai(w io.Writer, p *sudoku.Gattai) error {
+	bw := bufio.NewWriter(w)
+	for _, part := range p.Parts {
+		fmt.Fprintf(bw, "grid r%dc%d %d\n", part.Y+1, part.X+1, part.Grid.Size)
+	}
+	lines := make([]string, len(p.Parts))
+	for i, part := range p.Parts {
+		lines[i] = part.Grid.Line()
+	}
+	width, height := p.Size()
+	for y := 0; y < height; y++ {
+		row := []byte(strings.Repeat(" ", width))
+		for i, part := range p.Parts {
+			for x := part.X; x < part.X+part.Grid.Size; x++ {
+				if part.Covers(x, y) {
+					row[x] = lines[i][(y-part.Y)*part.Grid.Size+x-part.X]
+				}
+			}
+		}
+		bw.WriteString(strings.TrimRight(string(row), " ") + "\n")
+	}
+	return bw.Flush()
+}
+
+// readPart reads the position and optional size of a grid, e.g. r7c7 9
+func readPart(args []string) (sudoku.Part, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return sudoku.Part{}, fmt.Errorf("grid needs a position and an optional size")
+	}
+	var row, col int
+	if n, err := fmt.Sscanf(strings.ToLower(args[0]), "r%dc%d", &row, &col); n != 2 || err != nil || row < 1 || col < 1 {
+		return sudoku.Part{}, fmt.Errorf("invalid position '%s'", args[0])
+	}
+	size := 9
+	if len(args) == 2 {
+		var err error
+		if size, err = strconv.Atoi(args[1]); err != nil {
+			return sudoku.Part{}, fmt.Errorf("invalid size '%s'", args[1])
+		}
+	}
+	g, err := sudoku.NewGrid(size)
+	if err != nil {
+		return sudoku.Part{}, err
+	}
+	return sudoku.Part{X: col - 1, Y: row - 1, Grid: g}, nil
+}
+
+// gridSize returns the size of the first grid covering (x,y)
+func gridSize(p *sudoku.Gattai, x, y int) int {
+	for _, part := range p.Parts {
+		if part.Covers(x, y) {
+			return part.Grid.Size
+		}
+	}
+	return 0
+}
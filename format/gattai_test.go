@@ -0,0 +1,87 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testTwin is a layout of two 4x4 grids sharing a box
+const testTwin = `grid r1c1 4
+grid r3c3 4
+1...
+....
+..3...
+...2..
+  ....
+  ..4.
+`
+
+func TestReadGattai(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantParts int
+		wantEmpty int
+		wantErr   bool
+	}{
+		{name: "twin", input: testTwin, wantParts: 2, wantEmpty: 28 - 4},
+		{name: "samurai", input: "# empty\nsamurai\n" + strings.Repeat(".........   .........\n", 6) +
+			strings.Repeat(".....................\n", 3) + strings.Repeat("      .........\n", 3) +
+			strings.Repeat(".....................\n", 3) + strings.Repeat(".........   .........\n", 6),
+			wantParts: 5, wantEmpty: 5*81 - 4*9},
+		{name: "no layout", input: "1...\n....\n", wantErr: true},
+		{name: "empty", input: "# nothing\n", wantErr: true},
+		{name: "invalid position", input: "grid 1 1\n", wantErr: true},
+		{name: "invalid size", input: "grid r1c1 x\n", wantErr: true},
+		{name: "missing row", input: strings.TrimSuffix(testTwin, "  ..4.\n"), wantErr: true},
+		{name: "number outside", input: strings.Replace(testTwin, "  ....", "1 ....", 1), wantErr: true},
+		{name: "missing cell", input: strings.Replace(testTwin, "1...", "1 ..", 1), wantErr: true},
+		{name: "number too large", input: strings.Replace(testTwin, "1...", "5...", 1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadGattai(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadGattai() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Parts) != tt.wantParts || got.EmptyCells() != tt.wantEmpty {
+				t.Errorf("ReadGattai() has %d grids with %d empty cells, want %d with %d",
+					len(got.Parts), got.EmptyCells(), tt.wantParts, tt.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestWriteGattai(t *testing.T) {
+	p, err := ReadGattai(strings.NewReader(testTwin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num, _ := p.Get(3, 3); num != 2 || p.Parts[1].Grid.Get(1, 1) != 2 {
+		t.Errorf("ReadGattai() did not set shared cell in both grids")
+	}
+	var b bytes.Buffer
+	if err := WriteGattai(&b, p); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != testTwin {
+		t.Errorf("WriteGattai() = %s, want %s", b.String(), testTwin)
+	}
+}
+
+func TestReadGattaiFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twin"+GattaiExtension)
+	if err := os.WriteFile(path, []byte(testTwin), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := ReadGattaiFile(path); err != nil || len(p.Parts) != 2 {
+		t.Errorf("ReadGattaiFile() = %v, %v, want twin", p, err)
+	}
+}
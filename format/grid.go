@@ -0,0 +1,286 @@
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// ReadGrids reads all grids of any size from r
+// In Line and SDM files the size is the square root of the line length,
+// in CSV, SDK and SS files it is the number of cells in the first row.
+// The boxes are chosen by sudoku.BoxSize.
+func (f Format) ReadGrids(r io.Reader) ([]*sudoku.Grid, error) {
+	switch f {
+	case Line, SDM:
+		return readGridLines(r)
+	case CSV:
+		return readGridRows(r, splitCSV)
+	case SDK, SS:
+		return readGridRows(r, splitChars)
+	}
+	return nil, fmt.Errorf("Unknown format %s", f)
+}
+
+// WriteGrids writes all grids to w
+// Formats which hold a single grid separate multiple grids with an empty line
+func (f Format) WriteGrids(w io.Writer, grids ...*sudoku.Grid) error {
+	bw := bufio.NewWriter(w)
+	for i, g := range grids {
+		if i > 0 && f != Line && f != SDM {
+			bw.WriteString("\n")
+		}
+		switch f {
+		case Line:
+			bw.WriteString(g.Line() + "\n")
+		case SDM:
+			bw.WriteString(strings.Replace(g.Line(), ".", "0", -1) + "\n")
+		case CSV:
+			writeGridCSV(bw, g)
+		case SDK:
+			line := g.Line()
+			for y := 0; y < g.Size; y++ {
+				bw.WriteString(line[y*g.Size:(y+1)*g.Size] + "\n")
+			}
+		case SS:
+			writeGridSS(bw, g)
+		default:
+			return fmt.Errorf("Unknown format %s", f)
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadGridFile reads all grids from the file at path
+// The format is chosen like in ReadFile.
+func ReadGridFile(path string) ([]*sudoku.Grid, Format, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, ok := ByExtension(path)
+	if !ok {
+		f = Detect(data)
+	}
+	grids, err := f.ReadGrids(bytes.NewReader(data))
+	if err != nil && !ok && f == SDK {
+		// lines of small grids look like the rows of a large grid
+		if lines, lineErr := Line.ReadGrids(bytes.NewReader(data)); lineErr == nil {
+			return lines, Line, nil
+		}
+	}
+	return grids, f, err
+}
+
+// WriteGridFile writes all grids to the file at path
+// The format is chosen by the file extension, unknown extensions use Line
+func WriteGridFile(path string, grids ...*sudoku.Grid) error {
+	f, _ := ByExtension(path)
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.WriteGrids(file, grids...); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// scanLines calls fn with every line of r holding cells
+// Lines are trimmed, empty lines and comments starting with '#' are skipped,
+// e.g. the author (#A) and description (#D) of SadMan Sudoku.
+// Section headers like [Puzzle] are skipped too, reading stops at the [State]
+// section of a saved SadMan Sudoku game.
+func scanLines(r io.Reader, fn func(line int, text string) error) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.EqualFold(text, "[State]"):
+			return nil
+		case text == "", strings.HasPrefix(text, "#"), strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			continue
+		}
+		if err := fn(line, text); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// reads grids in line format, one grid per line
+// Everything after the first whitespace in a line is ignored.
+func readGridLines(r io.Reader) ([]*sudoku.Grid, error) {
+	var grids []*sudoku.Grid
+	err := scanLines(r, func(line int, text string) error {
+		g, err := sudoku.ParseGrid(strings.Fields(text)[0])
+		if err != nil {
+			return fmt.Errorf("Line %d: %s", line, err)
+		}
+		grids = append(grids, g)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("No sudoku found")
+	}
+	return grids, nil
+}
+
+// splitRow splits a line of a grid into its cells
+// Lines without cells return no cells and no error.
+type splitRow func(line string) ([]string, error)
+
+// splits a row of single characters, box separators and whitespace are ignored
+func splitChars(line string) ([]string, error) {
+	var cells []string
+	for _, c := range line {
+		if !strings.ContainsRune("|-+* \t", c) {
+			cells = append(cells, string(c))
+		}
+	}
+	return cells, nil
+}
+
+// splits a row of comma separated numbers, blank cells are empty
+func splitCSV(line string) ([]string, error) {
+	if strings.TrimSpace(line) == "" {
+		return nil, nil
+	}
+	cells, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range cells {
+		cells[i] = strings.TrimSpace(v)
+		if cells[i] == "" {
+			cells[i] = "0"
+		}
+	}
+	return cells, nil
+}
+
+// reads grids with one row per line
+// Lines without cells like box separators are skipped.
+// Every grid has as many rows as cells in its first row.
+func readGridRows(r io.Reader, split splitRow) ([]*sudoku.Grid, error) {
+	var grids []*sudoku.Grid
+	var g *sudoku.Grid
+	row := 0
+	err := scanLines(r, func(line int, text string) error {
+		cells, err := split(text)
+		if err != nil {
+			return fmt.Errorf("Line %d: %s", line, err)
+		}
+		if len(cells) == 0 {
+			return nil
+		}
+		if g == nil {
+			if g, err = sudoku.NewGrid(len(cells)); err != nil {
+				return fmt.Errorf("Line %d: %s", line, err)
+			}
+		}
+		if len(cells) != g.Size {
+			return fmt.Errorf("Line %d has %d instead of %d cells", line, len(cells), g.Size)
+		}
+		for x, cell := range cells {
+			num, err := parseCell(cell, g.Size)
+			if err != nil {
+				return fmt.Errorf("Line %d: %s", line, err)
+			}
+			g.Set(x, row, num)
+		}
+		row++
+		if row == g.Size {
+			grids = append(grids, g)
+			g, row = nil, 0
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if g != nil {
+		return nil, fmt.Errorf("Sudoku has %d instead of %d rows", row, g.Size)
+	}
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("No sudoku found")
+	}
+	return grids, nil
+}
+
+// parses a cell either as number or as single character like sudoku.ParseGrid
+// 'x' also marks an empty cell, unless it is a number of the grid.
+func parseCell(cell string, size int) (int, error) {
+	if num, err := strconv.Atoi(cell); err == nil {
+		if num < 0 || num > size {
+			return 0, fmt.Errorf("Number %d is not between 1 and %d", num, size)
+		}
+		return num, nil
+	}
+	r := []rune(strings.ToUpper(cell))
+	if len(r) != 1 {
+		return 0, fmt.Errorf("Invalid cell '%s'", cell)
+	}
+	num, err := sudoku.ParseDigit(r[0], size)
+	if err != nil && r[0] == 'X' {
+		return sudoku.EmptyCell, nil
+	}
+	return num, err
+}
+
+// writes a grid as csv with an empty line between the boxes
+func writeGridCSV(w *bufio.Writer, g *sudoku.Grid) {
+	for y := 0; y < g.Size; y++ {
+		if y > 0 && y%g.BoxHeight == 0 {
+			w.WriteString("\n")
+		}
+		for x := 0; x < g.Size; x++ {
+			switch {
+			case x == 0:
+			case x%g.BoxWidth == 0:
+				w.WriteString(", ")
+			default:
+				w.WriteString(",")
+			}
+			w.WriteString(strconv.Itoa(g.Get(x, y)))
+		}
+		w.WriteString("\n")
+	}
+}
+
+// writes a grid with box separators
+// e.g. a 6x6 grid:
+// *-------*
+// |1..|.5.|
+// |..4|1..|
+// |---+---|
+// ...
+// *-------*
+func writeGridSS(w *bufio.Writer, g *sudoku.Grid) {
+	line := g.Line()
+	boxes := g.Size / g.BoxWidth
+	border := "*" + strings.Repeat("-", g.Size+boxes-1) + "*\n"
+	separator := "|" + strings.Repeat(strings.Repeat("-", g.BoxWidth)+"+", boxes-1) + strings.Repeat("-", g.BoxWidth) + "|\n"
+	w.WriteString(border)
+	for y := 0; y < g.Size; y++ {
+		if y > 0 && y%g.BoxHeight == 0 {
+			w.WriteString(separator)
+		}
+		for x := 0; x < g.Size; x += g.BoxWidth {
+			w.WriteString("|" + line[y*g.Size+x:y*g.Size+x+g.BoxWidth])
+		}
+		w.WriteString("|\n")
+	}
+	w.WriteString(border)
+}
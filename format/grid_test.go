@@ -0,0 +1,211 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// testGrid6 is a 6x6 grid with 3x2 boxes
+var testGrid6 = &sudoku.Grid{
+	Size:      6,
+	BoxWidth:  3,
+	BoxHeight: 2,
+	Cells: []int{
+		1, 0, 0, 0, 5, 0,
+		0, 0, 4, 1, 0, 0,
+		0, 1, 0, 0, 0, 3,
+		0, 5, 0, 0, 0, 0,
+		0, 0, 0, 2, 0, 0,
+		0, 0, 0, 0, 1, 6,
+	},
+}
+
+const (
+	testGrid6Line = "1...5...41...1...3.5.......2......16\n"
+	testGrid6SS   = `*-------*
+|1..|.5.|
+|..4|1..|
+|---+---|
+|.1.|..3|
+|.5.|...|
+|---+---|
+|...|2..|
+|...|.16|
+*-------*
+`
+	testGrid6CSV = `1,0,0, 0,5,0
+0,0,4, 1,0,0
+
+0,1,0, 0,0,3
+0,5,0, 0,0,0
+
+0,0,0, 2,0,0
+0,0,0, 0,1,6
+`
+)
+
+// testGrid16 is a 16x16 grid with numbers above 9
+func testGrid16() *sudoku.Grid {
+	g, _ := sudoku.NewGrid(16)
+	for i := 0; i < 16; i++ {
+		g.Set(i, i, i+1)
+	}
+	return g
+}
+
+func TestFormat_ReadGrids(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       Format
+		input   string
+		want    []*sudoku.Grid
+		wantErr bool
+	}{
+		{
+			name:  "line",
+			f:     Line,
+			input: "# small grids\n" + testGrid6Line + testLine,
+			want:  []*sudoku.Grid{testGrid6, testField.Grid()},
+		},
+		{
+			name:  "sdm",
+			f:     SDM,
+			input: strings.Replace(testGrid6Line, ".", "0", -1),
+			want:  []*sudoku.Grid{testGrid6},
+		},
+		{
+			name:  "sdk",
+			f:     SDK,
+			input: testSDK,
+			want:  []*sudoku.Grid{testField.Grid()},
+		},
+		{
+			name:  "sdk with sections",
+			f:     SDK,
+			input: "[Puzzle]\n#Aauthor\n" + testSDK + "[State]\n" + testSDK,
+			want:  []*sudoku.Grid{testField.Grid()},
+		},
+		{
+			name:  "ss",
+			f:     SS,
+			input: testGrid6SS + testSS,
+			want:  []*sudoku.Grid{testGrid6, testField.Grid()},
+		},
+		{
+			name:  "csv",
+			f:     CSV,
+			input: testGrid6CSV,
+			want:  []*sudoku.Grid{testGrid6},
+		},
+		{
+			name:    "number too large",
+			f:       CSV,
+			input:   "12,,,\n,,,\n,,,\n,,,\n",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "missing rows",
+			f:       SS,
+			input:   testGrid6SS[:40],
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid size",
+			f:       Line,
+			input:   "1...5...41",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.ReadGrids(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Format.ReadGrids() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Format.ReadGrids() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_WriteGrids(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Format
+		want string
+	}{
+		{"line", Line, testGrid6Line},
+		{"ss", SS, testGrid6SS},
+		{"csv", CSV, testGrid6CSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.f.WriteGrids(&buf, testGrid6); err != nil {
+				t.Fatalf("Format.WriteGrids() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Format.WriteGrids() = %v, want %v", buf.String(), tt.want)
+			}
+		})
+	}
+
+	// every format reads what it writes
+	for _, f := range []Format{Line, CSV, SDK, SDM, SS} {
+		var buf bytes.Buffer
+		grids := []*sudoku.Grid{testGrid16(), testGrid6}
+		if err := f.WriteGrids(&buf, grids...); err != nil {
+			t.Fatalf("%s: Format.WriteGrids() error = %v", f, err)
+		}
+		got, err := f.ReadGrids(&buf)
+		if err != nil {
+			t.Errorf("%s: Format.ReadGrids() error = %v", f, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, grids) {
+			t.Errorf("%s: Format.ReadGrids() = %v, want %v", f, got, grids)
+		}
+	}
+}
+
+func TestReadGridFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// four 4x4 grids in line format without extension look like the rows of a 16x16 grid
+	small := filepath.Join(dir, "small")
+	os.WriteFile(small, []byte(strings.Repeat("1..2.3.....1.4..\n", 4)), 0644)
+	grids, f, err := ReadGridFile(small)
+	if err != nil || f != Line || len(grids) != 4 || grids[0].Size != 4 {
+		t.Errorf("ReadGridFile() = %d grids, %v, %v, want 4 grids of size 4 in line format", len(grids), f, err)
+	}
+
+	path := filepath.Join(dir, "large.sdk")
+	if err := WriteGridFile(path, testGrid16()); err != nil {
+		t.Fatalf("WriteGridFile() error = %v", err)
+	}
+	grids, f, err = ReadGridFile(path)
+	if err != nil || f != SDK || !reflect.DeepEqual(grids, []*sudoku.Grid{testGrid16()}) {
+		t.Errorf("ReadGridFile() = %v, %v, %v, want %v", grids, f, err, testGrid16())
+	}
+}
+
+func TestReadGridFile_SadMan(t *testing.T) {
+	grids, f, err := ReadGridFile(filepath.Join("testdata", "sadman.sdk"))
+	if err != nil {
+		t.Fatalf("ReadGridFile() error = %v", err)
+	}
+	if f != SDK || !reflect.DeepEqual(grids, []*sudoku.Grid{testField.Grid()}) {
+		t.Errorf("ReadGridFile() = %v, %v, want %v, %v", grids, f, testField.Grid(), SDK)
+	}
+}
@@ -0,0 +1,91 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// RegionExtension is the file extension of region maps
+const RegionExtension = ".reg"
+
+// ReadRegions reads the regions of a jigsaw sudoku from a region map
+// Every row of the grid is a line with one character per cell,
+// all cells with the same character form a region.
+// Whitespace is ignored, empty lines and lines starting with '#' are skipped.
+// e.g. a 4x4 jigsaw:
+// AABB
+// ACBB
+// ACDD
+// CCDD
+// The regions are numbered in the order of their first cell, see sudoku.Grid.Regions.
+func ReadRegions(r io.Reader) ([]int, error) {
+	var cells []rune
+	size := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var row []rune
+		for _, c := range text {
+			if !unicode.IsSpace(c) {
+				row = append(row, c)
+			}
+		}
+		if size == 0 {
+			size = len(row)
+		}
+		if len(row) != size {
+			return nil, fmt.Errorf("Line %d has %d instead of %d cells", line, len(row), size)
+		}
+		cells = append(cells, row...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("No regions found")
+	}
+	if len(cells) != size*size {
+		return nil, fmt.Errorf("Region map has %d instead of %d rows", len(cells)/size, size)
+	}
+	return parseRegions(cells, size)
+}
+
+// ReadRegionsFile reads the region map from the file at path
+func ReadRegionsFile(path string) ([]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRegions(file)
+}
+
+// parseRegions numbers the regions of a grid of size by the character of every cell
+func parseRegions(cells []rune, size int) ([]int, error) {
+	ids := make(map[rune]int)
+	regions := make([]int, len(cells))
+	for i, c := range cells {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		regions[i] = id
+	}
+	if len(ids) != size {
+		return nil, fmt.Errorf("Region map has %d instead of %d regions", len(ids), size)
+	}
+	if err := sudoku.CheckRegions(size, regions); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
@@ -0,0 +1,56 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "letters",
+			input: "AABB\nACBB\nACDD\nCCDD\n",
+			want:  []int{0, 0, 1, 1, 0, 2, 1, 1, 0, 2, 3, 3, 2, 2, 3, 3},
+		},
+		{
+			name:  "comments and whitespace",
+			input: "# jigsaw\n1 1 2 2\n1 3 2 2\n\n1 3 4 4\n3 3 4 4\n",
+			want:  []int{0, 0, 1, 1, 0, 2, 1, 1, 0, 2, 3, 3, 2, 2, 3, 3},
+		},
+		{name: "empty", input: "# nothing", wantErr: true},
+		{name: "short row", input: "AABB\nACB\nACDD\nCCDD\n", wantErr: true},
+		{name: "missing row", input: "AABB\nACBB\nACDD\n", wantErr: true},
+		{name: "too many regions", input: "AABB\nACBB\nACDD\nCCDE\n", wantErr: true},
+		{name: "not connected", input: "ABBB\nAABC\nCCDD\nCDDA\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRegions(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRegionsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jigsaw"+RegionExtension)
+	if err := os.WriteFile(path, []byte("AABB\nACBB\nACDD\nCCDD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadRegionsFile(path); err != nil || len(got) != 16 {
+		t.Errorf("ReadRegionsFile() = %v, %v, want 16 regions", got, err)
+	}
+}
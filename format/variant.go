@@ -0,0 +1,379 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// VariantExtension is the file extension of variant descriptions
+const VariantExtension = ".var"
+
+// variant is a puzzle while its description is read
+type variant struct {
+	grid   *sudoku.Grid
+	killer *sudoku.Killer
+	edges  *sudoku.Edges
+	parity *sudoku.Parity
+	// caged marks all cells which are already part of a cage
+	caged map[int]bool
+}
+
+// directive reads a line of a variant description starting with its name
+type directive func(v *variant, args []string) error
+
+// directives are all lines a variant description can contain
+var directives = map[string]directive{
+	"size": func(v *variant, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("size needs a single number")
+		}
+		if v.grid != nil {
+			return fmt.Errorf("size must be the first line")
+		}
+		size, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid size '%s'", args[0])
+		}
+		v.grid, err = sudoku.NewGrid(size)
+		return err
+	},
+	"grid": func(v *variant, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("grid needs the cells in line format")
+		}
+		g, err := sudoku.ParseGrid(args[0])
+		if err != nil {
+			return err
+		}
+		if v.grid != nil && (v.grid.Size != g.Size || len(v.grid.Constraints) > 0 || v.grid.Regions != nil || len(v.grid.Extra) > 0) {
+			return fmt.Errorf("grid must follow size and be in front of all rules")
+		}
+		v.grid = g
+		return nil
+	},
+	"regions": func(v *variant, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("regions needs a line with the region of every cell")
+		}
+		cells := []rune(args[0])
+		if len(cells) != len(v.grid.Cells) {
+			return fmt.Errorf("regions has %d instead of %d cells", len(cells), len(v.grid.Cells))
+		}
+		regions, err := parseRegions(cells, v.grid.Size)
+		if err != nil {
+			return err
+		}
+		v.grid.Regions = regions
+		return nil
+	},
+	"diagonals": func(v *variant, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("diagonals has no arguments")
+		}
+		v.grid.Extra = append(v.grid.Extra, sudoku.Diagonals(v.grid.Size)...)
+		return nil
+	},
+	"windows": func(v *variant, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("windows has no arguments")
+		}
+		v.grid.Extra = append(v.grid.Extra, sudoku.Windows(v.grid)...)
+		return nil
+	},
+	"anti-knight":     rule(sudoku.AntiKnight{}),
+	"anti-king":       rule(sudoku.AntiKing{}),
+	"non-consecutive": rule(sudoku.NonConsecutive{}),
+	"thermo": func(v *variant, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("thermo needs at least two cells")
+		}
+		cells, err := v.cells(args)
+		if err != nil {
+			return err
+		}
+		v.grid.Constraints = append(v.grid.Constraints, &sudoku.Thermometer{Cells: cells})
+		return nil
+	},
+	"arrow": func(v *variant, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("arrow needs a circle and at least one cell")
+		}
+		cells, err := v.cells(args)
+		if err != nil {
+			return err
+		}
+		v.grid.Constraints = append(v.grid.Constraints, &sudoku.Arrow{Circle: cells[0], Cells: cells[1:]})
+		return nil
+	},
+	"sandwich": func(v *variant, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("sandwich needs a row or column and a sum")
+		}
+		cells, err := v.line(args[0])
+		if err != nil {
+			return err
+		}
+		sum, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid sum '%s'", args[1])
+		}
+		v.grid.Constraints = append(v.grid.Constraints, &sudoku.Sandwich{Sum: sum, Cells: cells})
+		return nil
+	},
+	"white":   edge(sudoku.WhiteDot),
+	"black":   edge(sudoku.BlackDot),
+	"x":       edge(sudoku.SumX),
+	"v":       edge(sudoku.SumV),
+	"greater": edge(sudoku.Greater),
+	"even": func(v *variant, args []string) error {
+		cells, err := v.parityCells(args)
+		if err != nil {
+			return err
+		}
+		v.parity.Even = append(v.parity.Even, cells...)
+		return nil
+	},
+	"odd": func(v *variant, args []string) error {
+		cells, err := v.parityCells(args)
+		if err != nil {
+			return err
+		}
+		v.parity.Odd = append(v.parity.Odd, cells...)
+		return nil
+	},
+	"little-killer": func(v *variant, args []string) error {
+		if len(args) != 3 {
+			return fmt.Errorf("little-killer needs a position outside of the grid, a direction and a sum")
+		}
+		var row, col int
+		if n, err := fmt.Sscanf(strings.ToLower(args[0]), "r%dc%d", &row, &col); n != 2 || err != nil {
+			return fmt.Errorf("invalid position '%s'", args[0])
+		}
+		n := v.grid.Size
+		if row < 0 || row > n+1 || col < 0 || col > n+1 || (row >= 1 && row <= n && col >= 1 && col <= n) {
+			return fmt.Errorf("position %s is not next to the grid", args[0])
+		}
+		dir, ok := directions[strings.ToLower(args[1])]
+		if !ok {
+			return fmt.Errorf("invalid direction '%s'", args[1])
+		}
+		cells := sudoku.LittleKillerCells(v.grid, row-1, col-1, dir[0], dir[1])
+		if len(cells) == 0 {
+			return fmt.Errorf("diagonal from %s into %s misses the grid", args[0], args[1])
+		}
+		sum, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid sum '%s'", args[2])
+		}
+		v.grid.Constraints = append(v.grid.Constraints, &sudoku.LittleKiller{Sum: sum, Cells: cells})
+		return nil
+	},
+	"cage": func(v *variant, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("cage needs a sum and at least one cell")
+		}
+		sum, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid sum '%s'", args[0])
+		}
+		cells, err := v.cells(args[1:])
+		if err != nil {
+			return err
+		}
+		for _, i := range cells {
+			if v.caged[i] {
+				return fmt.Errorf("cell %s is in more than one cage", cellName(v.grid, i))
+			}
+			v.caged[i] = true
+		}
+		if v.killer == nil {
+			v.killer = &sudoku.Killer{}
+			v.grid.Constraints = append(v.grid.Constraints, v.killer)
+		}
+		v.killer.Cages = append(v.killer.Cages, sudoku.Cage{Sum: sum, Cells: cells})
+		return nil
+	},
+}
+
+// directions are the rows and columns of a step along a little killer diagonal
+var directions = map[string][2]int{
+	"ne": {-1, 1},
+	"se": {1, 1},
+	"sw": {1, -1},
+	"nw": {-1, -1},
+}
+
+// rule returns the directive adding a constraint without arguments
+func rule(c sudoku.Constraint) directive {
+	return func(v *variant, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("rule has no arguments")
+		}
+		v.grid.Constraints = append(v.grid.Constraints, c)
+		return nil
+	}
+}
+
+// edge returns the directive adding a clue of kind between two adjacent cells
+func edge(kind sudoku.EdgeKind) directive {
+	return func(v *variant, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("%s needs two cells", kind)
+		}
+		cells, err := v.cells(args)
+		if err != nil {
+			return err
+		}
+		a, b := cells[0], cells[1]
+		rows, cols := a/v.grid.Size-b/v.grid.Size, a%v.grid.Size-b%v.grid.Size
+		if rows*rows+cols*cols != 1 {
+			return fmt.Errorf("cells %s and %s are not adjacent", args[0], args[1])
+		}
+		if v.edges == nil {
+			v.edges = &sudoku.Edges{}
+			v.grid.Constraints = append(v.grid.Constraints, v.edges)
+		}
+		v.edges.Clues = append(v.edges.Clues, sudoku.Edge{Kind: kind, A: a, B: b})
+		return nil
+	}
+}
+
+// ReadVariant reads a sudoku variant from its description
+// Every line starts with a directive followed by its arguments,
+// empty lines and lines starting with '#' are skipped.
+// Cells are given as row and column starting at 1, e.g. r1c2 is the second cell in the first row.
+// e.g. a killer sudoku:
+// size 9
+// grid .....3...........................................................................
+// cage 3 r1c1 r1c2
+// cage 15 r1c3 r2c3 r3c3
+// A jigsaw sudoku has a line with the regions of all cells, like in ReadRegions:
+// regions AABBACBBACDDCCDD
+// The diagonals of a Sudoku-X and the windows of a Windoku are added by a line without arguments:
+// diagonals
+// windows
+// The rules anti-knight, anti-king and non-consecutive are added the same way.
+// Thermometers list their cells from the bulb, arrows from the circle,
+// sandwiches name a row or column followed by the sum:
+// thermo r1c1 r1c2 r2c3
+// arrow r5c5 r4c4 r3c3
+// sandwich c4 12
+// Clues between two adjacent cells are a white or black kropki dot, an X or V
+// or a greater than sign with the greater cell first:
+// white r1c1 r1c2
+// black r1c1 r2c1
+// x r2c2 r2c3
+// v r3c3 r4c3
+// greater r5c5 r5c6
+// Shaded cells hold an even or odd number,
+// little killer sums lie outside of the grid in row or column 0 or size+1
+// and point along a diagonal into the direction ne, se, sw or nw:
+// even r1c1 r2c2
+// odd r1c3
+// little-killer r0c3 se 15
+// The grid is empty with a size of 9 if neither size nor grid are given.
+func ReadVariant(r io.Reader) (*sudoku.Grid, error) {
+	v := &variant{caged: make(map[int]bool)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		words := strings.Fields(scanner.Text())
+		if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+			continue
+		}
+		name := strings.ToLower(words[0])
+		d, ok := directives[name]
+		if !ok {
+			return nil, fmt.Errorf("Line %d: unknown directive '%s'", line, words[0])
+		}
+		if v.grid == nil && name != "size" && name != "grid" {
+			v.grid, _ = sudoku.NewGrid(9)
+		}
+		if err := d(v, words[1:]); err != nil {
+			return nil, fmt.Errorf("Line %d: %s", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if v.grid == nil {
+		return nil, fmt.Errorf("No sudoku found")
+	}
+	return v.grid, nil
+}
+
+// ReadVariantFile reads the description of a variant from the file at path
+func ReadVariantFile(path string) (*sudoku.Grid, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadVariant(file)
+}
+
+// cells parses cell names like r1c2 into their indices in the grid
+func (v *variant) cells(names []string) ([]int, error) {
+	cells := make([]int, len(names))
+	for j, name := range names {
+		var row, col int
+		if n, err := fmt.Sscanf(strings.ToLower(name), "r%dc%d", &row, &col); n != 2 || err != nil {
+			return nil, fmt.Errorf("invalid cell '%s'", name)
+		}
+		if row < 1 || row > v.grid.Size || col < 1 || col > v.grid.Size {
+			return nil, fmt.Errorf("cell %s is outside of the grid", name)
+		}
+		cells[j] = (row-1)*v.grid.Size + col - 1
+	}
+	return cells, nil
+}
+
+// parityCells parses the cells of an even or odd line and adds the parity rule to the grid
+func (v *variant) parityCells(names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("even and odd need at least one cell")
+	}
+	cells, err := v.cells(names)
+	if err != nil {
+		return nil, err
+	}
+	if v.parity == nil {
+		v.parity = &sudoku.Parity{}
+		v.grid.Constraints = append(v.grid.Constraints, v.parity)
+	}
+	return cells, nil
+}
+
+// line parses the name of a row like r3 or a column like c5 into the indices of its cells
+func (v *variant) line(name string) ([]int, error) {
+	n := v.grid.Size
+	if len(name) < 2 {
+		return nil, fmt.Errorf("invalid row or column '%s'", name)
+	}
+	index, err := strconv.Atoi(name[1:])
+	if err != nil || index < 1 || index > n {
+		return nil, fmt.Errorf("invalid row or column '%s'", name)
+	}
+	cells := make([]int, n)
+	for j := range cells {
+		switch strings.ToLower(name[:1]) {
+		case "r":
+			cells[j] = (index-1)*n + j
+		case "c":
+			cells[j] = j*n + index - 1
+		default:
+			return nil, fmt.Errorf("invalid row or column '%s'", name)
+		}
+	}
+	return cells, nil
+}
+
+// cellName returns the name of the cell with index i, see ReadVariant
+func cellName(g *sudoku.Grid, i int) string {
+	return fmt.Sprintf("r%dc%d", i/g.Size+1, i%g.Size+1)
+}
@@ -0,0 +1,183 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+func TestReadVariant(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantSize  int
+		wantLine  string
+		wantCages []sudoku.Cage
+		wantExtra int
+		wantRules int
+		wantErr   bool
+	}{
+		{
+			name: "killer",
+			input: `# a small killer sudoku
+size 4
+
+cage 3 r1c1 r1c2
+Cage 7 R1C3 r1c4 r2c4
+`,
+			wantSize: 4,
+			wantLine: "................",
+			wantCages: []sudoku.Cage{
+				{Sum: 3, Cells: []int{0, 1}},
+				{Sum: 7, Cells: []int{2, 3, 7}},
+			},
+		},
+		{
+			name:     "grid",
+			input:    "grid 1..2.3.....1.4..\ncage 10 r4c1 r4c2\n",
+			wantSize: 4,
+			wantLine: "1..2.3.....1.4..",
+			wantCages: []sudoku.Cage{
+				{Sum: 10, Cells: []int{12, 13}},
+			},
+		},
+		{
+			name:      "default size",
+			input:     "cage 17 r9c8 r9c9",
+			wantSize:  9,
+			wantLine:  strings.Repeat(".", 81),
+			wantCages: []sudoku.Cage{{Sum: 17, Cells: []int{79, 80}}},
+		},
+		{
+			name:     "size and grid",
+			input:    "size 4\ngrid 1..2.3.....1.4..",
+			wantSize: 4,
+			wantLine: "1..2.3.....1.4..",
+		},
+		{
+			name:     "jigsaw",
+			input:    "grid 1...............\nregions AABBACBBACDDCCDD\ncage 5 r4c3 r4c4",
+			wantSize: 4,
+			wantLine: "1...............",
+			wantCages: []sudoku.Cage{
+				{Sum: 5, Cells: []int{14, 15}},
+			},
+		},
+		{
+			name:      "Sudoku-X and Windoku",
+			input:     "size 9\ndiagonals\nwindows\n",
+			wantSize:  9,
+			wantLine:  strings.Repeat(".", 81),
+			wantExtra: 6,
+		},
+		{
+			name:      "thermometer, arrow and sandwich",
+			input:     "size 4\nthermo r1c1 r1c2 r2c2\narrow r4c4 r3c3 r3c4\nsandwich c2 3\nsandwich R1 0",
+			wantSize:  4,
+			wantLine:  "................",
+			wantRules: 4,
+		},
+		{
+			name:      "edges",
+			input:     "size 4\nwhite r1c1 r1c2\nblack r1c1 r2c1\nX r2c2 r2c3\nv r3c3 r4c3\ngreater r4c4 r4c3",
+			wantSize:  4,
+			wantLine:  "................",
+			wantRules: 1,
+		},
+		{
+			name:      "even, odd and little killer",
+			input:     "size 4\neven r1c1 r2c2\nodd r1c3\neven r4c4\nlittle-killer r0c2 SE 7\nlittle-killer r5c5 nw 10",
+			wantSize:  4,
+			wantLine:  "................",
+			wantRules: 3,
+		},
+		{
+			name:      "negative constraints",
+			input:     "anti-knight\nAnti-King\nnon-consecutive\n",
+			wantSize:  9,
+			wantLine:  strings.Repeat(".", 81),
+			wantRules: 3,
+		},
+		{name: "empty", input: "# nothing\n", wantErr: true},
+		{name: "diagonals with argument", input: "diagonals 2", wantErr: true},
+		{name: "rule with argument", input: "anti-knight r1c1", wantErr: true},
+		{name: "short thermometer", input: "thermo r1c1", wantErr: true},
+		{name: "arrow without cells", input: "arrow r1c1", wantErr: true},
+		{name: "sandwich in box", input: "sandwich b1 3", wantErr: true},
+		{name: "sandwich outside", input: "sandwich r10 3", wantErr: true},
+		{name: "invalid sandwich sum", input: "sandwich r1 x", wantErr: true},
+		{name: "edge with one cell", input: "white r1c1", wantErr: true},
+		{name: "edge between distant cells", input: "black r1c1 r1c3", wantErr: true},
+		{name: "edge between diagonal cells", input: "x r1c1 r2c2", wantErr: true},
+		{name: "edge between rows", input: "v r1c9 r2c1", wantErr: true},
+		{name: "even without cells", input: "even", wantErr: true},
+		{name: "little killer inside", input: "little-killer r1c1 se 10", wantErr: true},
+		{name: "little killer far outside", input: "little-killer r0c11 sw 10", wantErr: true},
+		{name: "little killer missing the grid", input: "little-killer r0c1 sw 10", wantErr: true},
+		{name: "little killer direction", input: "little-killer r0c1 s 10", wantErr: true},
+		{name: "invalid little killer sum", input: "little-killer r0c1 se x", wantErr: true},
+		{name: "invalid regions", input: "size 4\nregions AABBACBBACDDCCDE", wantErr: true},
+		{name: "grid after regions", input: "size 4\nregions AABBACBBACDDCCDD\ngrid 1...............", wantErr: true},
+		{name: "unknown directive", input: "quadruple r1c1 1 2", wantErr: true},
+		{name: "size after cage", input: "cage 3 r1c1 r1c2\nsize 4", wantErr: true},
+		{name: "grid of other size", input: "size 6\ngrid 1..2.3.....1.4..", wantErr: true},
+		{name: "cell outside", input: "size 4\ncage 3 r1c1 r1c5", wantErr: true},
+		{name: "invalid cell", input: "cage 3 a1 a2", wantErr: true},
+		{name: "invalid sum", input: "cage x r1c1", wantErr: true},
+		{name: "overlapping cages", input: "cage 3 r1c1 r1c2\ncage 4 r1c2 r1c3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadVariant(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadVariant() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Size != tt.wantSize || got.Line() != tt.wantLine {
+				t.Errorf("ReadVariant() = %s with size %d, want %s with size %d", got.Line(), got.Size, tt.wantLine, tt.wantSize)
+			}
+			if len(got.Extra) != tt.wantExtra {
+				t.Errorf("ReadVariant() has %d extra houses, want %d", len(got.Extra), tt.wantExtra)
+			}
+			var cages []sudoku.Cage
+			rules := 0
+			for _, c := range got.Constraints {
+				if k, ok := c.(*sudoku.Killer); ok {
+					cages = append(cages, k.Cages...)
+				} else {
+					rules++
+				}
+			}
+			if rules != tt.wantRules {
+				t.Errorf("ReadVariant() has %d rules, want %d", rules, tt.wantRules)
+			}
+			if !reflect.DeepEqual(cages, tt.wantCages) {
+				t.Errorf("ReadVariant() has cages %v, want %v", cages, tt.wantCages)
+			}
+		})
+	}
+}
+
+func TestReadVariantFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "killer"+VariantExtension)
+	if err := os.WriteFile(path, []byte("size 4\ncage 3 r1c1 r1c2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := ReadVariantFile(path)
+	if err != nil {
+		t.Fatalf("ReadVariantFile() error = %v", err)
+	}
+	if g.Size != 4 || len(g.Constraints) != 1 {
+		t.Errorf("ReadVariantFile() = %v with %d rules, want 4x4 killer", g, len(g.Constraints))
+	}
+	if _, err := ReadVariantFile(filepath.Join(t.TempDir(), "missing.var")); err == nil {
+		t.Errorf("ReadVariantFile() of missing file returned no error")
+	}
+}
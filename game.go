@@ -0,0 +1,166 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrGivenCell is returned when a move tries to change a given cell of a game
+var ErrGivenCell = errors.New("given cells cannot be changed")
+
+// Action is the kind of a move in a game
+type Action string
+
+const (
+	// SetNumber sets the number of a cell
+	SetNumber = Action("set")
+
+	// ClearCell removes the number and all candidates of a cell
+	ClearCell = Action("clear")
+
+	// ToggleCandidate adds or removes a candidate of a cell
+	ToggleCandidate = Action("toggle")
+)
+
+// Move is a single change to a cell of a game
+type Move struct {
+	Action Action `json:"action"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	// Num is the set number or the toggled candidate
+	Num int `json:"number,omitempty"`
+	// PrevNum and PrevCandidates are the content of the cell before the move
+	PrevNum        int           `json:"prevNumber"`
+	PrevCandidates Possibilities `json:"prevCandidates"`
+}
+
+// Game is a sudoku solved by a player
+// It keeps the history of all moves, so they can be undone and redone.
+// The given cells cannot be changed.
+type Game struct {
+	givens     Field
+	field      Field
+	candidates [9][9]Possibilities
+	history    []Move
+	undone     []Move
+}
+
+// NewGame starts a game with the given cells
+func NewGame(givens Field) *Game {
+	return &Game{givens: givens, field: givens}
+}
+
+// Givens returns the field the game started with
+func (g *Game) Givens() Field {
+	return g.givens
+}
+
+// Field returns the current field
+func (g *Game) Field() Field {
+	return g.field
+}
+
+// Candidates returns the candidates marked by the player for every cell
+func (g *Game) Candidates() *SolverField {
+	var c SolverField
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			pos := g.candidates[i][j]
+			c[i][j] = &pos
+		}
+	}
+	return &c
+}
+
+// History returns all moves in the order they were made
+// Undone moves are not part of the history.
+func (g *Game) History() []Move {
+	return append([]Move(nil), g.history...)
+}
+
+// Set sets the number of the cell at (x,y)
+func (g *Game) Set(x, y, num int) error {
+	if num < 1 || num > 9 {
+		return fmt.Errorf("invalid number %d", num)
+	}
+	return g.play(Move{Action: SetNumber, X: x, Y: y, Num: num})
+}
+
+// Clear removes the number and all candidates of the cell at (x,y)
+func (g *Game) Clear(x, y int) error {
+	return g.play(Move{Action: ClearCell, X: x, Y: y})
+}
+
+// Toggle adds or removes the candidate num of the cell at (x,y)
+func (g *Game) Toggle(x, y, num int) error {
+	if num < 1 || num > 9 {
+		return fmt.Errorf("invalid number %d", num)
+	}
+	return g.play(Move{Action: ToggleCandidate, X: x, Y: y, Num: num})
+}
+
+// Undo reverts the last move and returns it
+func (g *Game) Undo() (Move, bool) {
+	if len(g.history) == 0 {
+		return Move{}, false
+	}
+	m := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.field[m.Y][m.X], g.candidates[m.Y][m.X] = m.PrevNum, m.PrevCandidates
+	g.undone = append(g.undone, m)
+	return m, true
+}
+
+// Redo makes the last undone move again and returns it
+func (g *Game) Redo() (Move, bool) {
+	if len(g.undone) == 0 {
+		return Move{}, false
+	}
+	m := g.undone[len(g.undone)-1]
+	g.undone = g.undone[:len(g.undone)-1]
+	g.apply(m)
+	g.history = append(g.history, m)
+	return m, true
+}
+
+// Solved checks if all cells are filled according to the sudoku rules
+func (g *Game) Solved() bool {
+	return g.field.EmptyCells() == 0 && g.field.Check() == nil
+}
+
+// play makes a new move, which clears all undone moves
+// Moves which do not change the cell are ignored.
+func (g *Game) play(m Move) error {
+	if m.X < 0 || m.X > 8 || m.Y < 0 || m.Y > 8 {
+		return fmt.Errorf("invalid cell (%d,%d)", m.X, m.Y)
+	}
+	if g.givens[m.Y][m.X] != EmptyCell {
+		return ErrGivenCell
+	}
+	m.PrevNum, m.PrevCandidates = g.field[m.Y][m.X], g.candidates[m.Y][m.X]
+	g.apply(m)
+	if g.field[m.Y][m.X] == m.PrevNum && g.candidates[m.Y][m.X] == m.PrevCandidates {
+		return nil
+	}
+	g.history = append(g.history, m)
+	g.undone = nil
+	return nil
+}
+
+// apply changes the cell of the move
+func (g *Game) apply(m Move) {
+	switch m.Action {
+	case SetNumber:
+		g.field[m.Y][m.X] = m.Num
+	case ClearCell:
+		g.field[m.Y][m.X] = EmptyCell
+		g.candidates[m.Y][m.X] = Possibilities{}
+	case ToggleCandidate:
+		c := &g.candidates[m.Y][m.X]
+		if c.IsPossible(m.Num) {
+			c.Remove(m.Num)
+		} else {
+			c.Add(m.Num)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGame(t *testing.T) {
+	// play applies a move to the game
+	type play func(g *Game) error
+	set := func(x, y, num int) play { return func(g *Game) error { return g.Set(x, y, num) } }
+	clear := func(x, y int) play { return func(g *Game) error { return g.Clear(x, y) } }
+	toggle := func(x, y, num int) play { return func(g *Game) error { return g.Toggle(x, y, num) } }
+	undo := func(g *Game) error { g.Undo(); return nil }
+	redo := func(g *Game) error { g.Redo(); return nil }
+
+	tests := []struct {
+		name       string
+		moves      []play
+		x, y       int
+		want       int
+		candidates Possibilities
+		history    int
+		wantErr    bool
+	}{
+		{
+			name:    "set number",
+			moves:   []play{set(1, 0, 2)},
+			x:       1,
+			y:       0,
+			want:    2,
+			history: 1,
+		},
+		{
+			name:    "given cell",
+			moves:   []play{set(0, 0, 2)},
+			x:       0,
+			y:       0,
+			want:    7,
+			history: 0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid number",
+			moves:   []play{set(1, 0, 10)},
+			x:       1,
+			y:       0,
+			want:    0,
+			history: 0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid cell",
+			moves:   []play{clear(9, 0)},
+			history: 0,
+			want:    7,
+			wantErr: true,
+		},
+		{
+			name:       "toggle candidates",
+			moves:      []play{toggle(1, 0, 2), toggle(1, 0, 5), toggle(1, 0, 2)},
+			x:          1,
+			y:          0,
+			want:       0,
+			candidates: Possibilities{false, false, false, false, true},
+			history:    3,
+		},
+		{
+			name:    "clear",
+			moves:   []play{toggle(1, 0, 2), set(1, 0, 5), clear(1, 0)},
+			x:       1,
+			y:       0,
+			want:    0,
+			history: 3,
+		},
+		{
+			name:    "unchanged cell is ignored",
+			moves:   []play{set(1, 0, 2), set(1, 0, 2), clear(2, 0)},
+			x:       1,
+			y:       0,
+			want:    2,
+			history: 1,
+		},
+		{
+			name:       "undo",
+			moves:      []play{toggle(1, 0, 2), set(1, 0, 5), undo},
+			x:          1,
+			y:          0,
+			want:       0,
+			candidates: Possibilities{false, true},
+			history:    1,
+		},
+		{
+			name:       "undo clear",
+			moves:      []play{toggle(1, 0, 2), set(1, 0, 5), clear(1, 0), undo},
+			x:          1,
+			y:          0,
+			want:       5,
+			candidates: Possibilities{false, true},
+			history:    2,
+		},
+		{
+			name:    "redo",
+			moves:   []play{set(1, 0, 2), set(1, 0, 5), undo, undo, redo},
+			x:       1,
+			y:       0,
+			want:    2,
+			history: 1,
+		},
+		{
+			name:    "new move clears redo",
+			moves:   []play{set(1, 0, 2), undo, set(1, 0, 6), redo},
+			x:       1,
+			y:       0,
+			want:    6,
+			history: 1,
+		},
+		{
+			name:    "undo without moves",
+			moves:   []play{undo},
+			want:    7,
+			history: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(*testField)
+			var err error
+			for _, m := range tt.moves {
+				if e := m(g); e != nil {
+					err = e
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Game move error = %v, wantErr %v", err, tt.wantErr)
+			}
+			f := g.Field()
+			if f[tt.y][tt.x] != tt.want {
+				t.Errorf("Game.Field() cell (%d,%d) = %d, want %d", tt.x, tt.y, f[tt.y][tt.x], tt.want)
+			}
+			if got := g.Candidates()[tt.y][tt.x]; !reflect.DeepEqual(*got, tt.candidates) {
+				t.Errorf("Game.Candidates() cell (%d,%d) = %v, want %v", tt.x, tt.y, got, tt.candidates)
+			}
+			if got := len(g.History()); got != tt.history {
+				t.Errorf("Game.History() has %d moves, want %d", got, tt.history)
+			}
+			if g.Givens() != *testField {
+				t.Errorf("Game.Givens() = %v, want %v", g.Givens(), testField)
+			}
+		})
+	}
+}
+
+func TestGame_Solved(t *testing.T) {
+	g := NewGame(*testField)
+	if g.Solved() {
+		t.Errorf("Game.Solved() = true for unsolved field")
+	}
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			if testField[i][j] == EmptyCell {
+				g.Set(j, i, testFieldSolved[i][j])
+			}
+		}
+	}
+	if !g.Solved() {
+		t.Errorf("Game.Solved() = false for solved field")
+	}
+	// swap two numbers, so the field is full but wrong
+	g.Set(1, 0, 5)
+	g.Set(2, 0, 2)
+	if g.Solved() {
+		t.Errorf("Game.Solved() = true for field with errors")
+	}
+}
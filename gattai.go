@@ -0,0 +1,309 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// Gattai is a puzzle of several overlapping grids, e.g. a samurai sudoku
+// All grids are placed on a common layout, grids covering the same position share its cell.
+type Gattai struct {
+	Parts []Part
+}
+
+// Part is a grid of a gattai puzzle with its top left cell at (X,Y) of the layout
+type Part struct {
+	X, Y int
+	Grid *Grid
+}
+
+// GattaiStepFunc is called when the solver sets a cell of a gattai puzzle
+// The coordinates of the step are positions in the layout.
+type GattaiStepFunc func(p Gattai, s Step)
+
+// Samurai creates an empty samurai sudoku
+// Four 9x9 grids in the corners share a box with the grid in the center,
+// e.g. the boxes of the layout:
+// ▣▣▣·▣▣▣
+// ▣▣▣·▣▣▣
+// ▣▣▣▣▣▣▣
+// ··▣▣▣··
+// ▣▣▣▣▣▣▣
+// ▣▣▣·▣▣▣
+// ▣▣▣·▣▣▣
+func Samurai() *Gattai {
+	p := &Gattai{}
+	for _, pos := range [][2]int{{0, 0}, {12, 0}, {6, 6}, {0, 12}, {12, 12}} {
+		p.Parts = append(p.Parts, Part{X: pos[0], Y: pos[1], Grid: NewGridBoxes(3, 3)})
+	}
+	return p
+}
+
+// Size returns the width and height of the layout
+func (p *Gattai) Size() (width, height int) {
+	for _, part := range p.Parts {
+		if w := part.X + part.Grid.Size; w > width {
+			width = w
+		}
+		if h := part.Y + part.Grid.Size; h > height {
+			height = h
+		}
+	}
+	return width, height
+}
+
+// Clone returns a copy of the puzzle and all of its grids
+func (p *Gattai) Clone() *Gattai {
+	c := &Gattai{Parts: make([]Part, len(p.Parts))}
+	for i, part := range p.Parts {
+		c.Parts[i] = Part{X: part.X, Y: part.Y, Grid: part.Grid.Clone()}
+	}
+	return c
+}
+
+// Get returns the number at (x,y) of the layout
+// It returns false if no grid covers the position.
+func (p *Gattai) Get(x, y int) (int, bool) {
+	for _, part := range p.Parts {
+		if part.Covers(x, y) {
+			return part.Grid.Get(x-part.X, y-part.Y), true
+		}
+	}
+	return EmptyCell, false
+}
+
+// Set sets the number at (x,y) of the layout in all grids covering the position
+func (p *Gattai) Set(x, y, num int) {
+	for _, part := range p.Parts {
+		if part.Covers(x, y) {
+			part.Grid.Set(x-part.X, y-part.Y, num)
+		}
+	}
+}
+
+// EmptyCells returns the count of empty cells of the layout
+func (p *Gattai) EmptyCells() int {
+	width, height := p.Size()
+	var empty int
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if num, ok := p.Get(x, y); ok && num == EmptyCell {
+				empty++
+			}
+		}
+	}
+	return empty
+}
+
+// Covers checks if the position (x,y) of the layout is part of the grid
+func (part Part) Covers(x, y int) bool {
+	return x >= part.X && x < part.X+part.Grid.Size && y >= part.Y && y < part.Y+part.Grid.Size
+}
+
+// Check validates every grid and that all grids hold the same numbers in shared cells
+func (p *Gattai) Check() error {
+	if len(p.Parts) == 0 {
+		return fmt.Errorf("Gattai has no grids")
+	}
+	for i, part := range p.Parts {
+		if part.X < 0 || part.Y < 0 {
+			return fmt.Errorf("Grid %d is placed outside of the layout at (%d,%d)", i+1, part.X, part.Y)
+		}
+		if err := part.Grid.Check(); err != nil {
+			return fmt.Errorf("Grid %d at (%d,%d): %w", i+1, part.X, part.Y, err)
+		}
+	}
+	width, height := p.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			num, _ := p.Get(x, y)
+			for _, part := range p.Parts {
+				if part.Covers(x, y) && part.Grid.Get(x-part.X, y-part.Y) != num {
+					return fmt.Errorf("Grids hold different numbers in shared cell (%d,%d)", x, y)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SolveGattai solves all grids of the puzzle
+// All grids are solved together like a single grid on their combined model, see Gattai.Model,
+// a step is a naked single or a hidden single in a house of any grid.
+func SolveGattai(p Gattai, onStep GattaiStepFunc) (*Gattai, error) {
+	solved := p.Clone()
+	if err := solved.Check(); err != nil {
+		return solved, fmt.Errorf("gattai is invalid: %w", err)
+	}
+	width, _ := solved.Size()
+	m, positions := solved.Model()
+	max := 0
+	for _, part := range solved.Parts {
+		if part.Grid.Size > max {
+			max = part.Grid.Size
+		}
+	}
+	err := solveModel(context.Background(), m, solved.houses(), max, func(v, num int, s Strategy) {
+		x, y := positions[v]%width, positions[v]/width
+		solved.Set(x, y, num)
+		if onStep != nil {
+			onStep(*solved.Clone(), Step{X: x, Y: y, Num: num, Strategy: s})
+		}
+	})
+	return solved, err
+}
+
+// SolveGattaiModel finds a solution of any solvable puzzle by searching its model
+// Unlike SolveGattai, it never gets stuck, but cannot explain its steps.
+func SolveGattaiModel(ctx context.Context, p Gattai) (*Gattai, error) {
+	if err := p.Check(); err != nil {
+		return nil, fmt.Errorf("gattai is invalid: %w", err)
+	}
+	m, positions := p.Model()
+	values, err := m.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	width, _ := p.Size()
+	solved := p.Clone()
+	for v, num := range values {
+		solved.Set(positions[v]%width, positions[v]/width, num)
+	}
+	return solved, nil
+}
+
+// Model turns the puzzle into a model with a variable per cell of the layout
+// The variables are numbered in reading order of the layout, positions maps them to their index in it.
+// A shared cell is a single variable in the houses and constraints of all grids covering it,
+// so its domain holds only the numbers possible in all of them.
+func (p *Gattai) Model() (m *Model, positions []int) {
+	vars, positions := p.variables()
+	m = &Model{Values: make([]int, len(positions)), Domains: make([]Candidates, len(positions))}
+	for v := range m.Domains {
+		m.Domains[v] = AllCandidates(MaxSize)
+	}
+	for j, part := range p.Parts {
+		g := part.Grid
+		gm := g.Model()
+		for i, v := range vars[j] {
+			m.Values[v] = gm.Values[i]
+			m.Domains[v] &= gm.Domains[i]
+		}
+		for _, h := range g.Houses() {
+			m.Add(&AllDifferent{Vars: mapVars(h.Cells, vars[j])})
+		}
+		for _, c := range g.Constraints {
+			for _, prop := range c.Propagators(g) {
+				m.Add(&gridPropagator{p: prop, vars: vars[j], scope: mapVars(prop.Scope(), vars[j])})
+			}
+		}
+	}
+	return m, positions
+}
+
+// houses returns the houses of all grids with the variables of the model as cells, see Gattai.Model
+func (p *Gattai) houses() []House {
+	vars, _ := p.variables()
+	var houses []House
+	for j, part := range p.Parts {
+		for _, h := range part.Grid.Houses() {
+			houses = append(houses, House{Type: h.Type, Cells: mapVars(h.Cells, vars[j])})
+		}
+	}
+	return houses
+}
+
+// variables numbers the cells of the layout in reading order
+// It returns the variable of every cell of every grid and the index in the layout of every variable.
+func (p *Gattai) variables() (vars [][]int, positions []int) {
+	width, height := p.Size()
+	index := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if _, ok := p.Get(x, y); ok {
+				index[y*width+x] = len(positions)
+				positions = append(positions, y*width+x)
+			}
+		}
+	}
+	vars = make([][]int, len(p.Parts))
+	for j, part := range p.Parts {
+		size := part.Grid.Size
+		vars[j] = make([]int, len(part.Grid.Cells))
+		for i := range vars[j] {
+			vars[j][i] = index[(part.Y+i/size)*width+part.X+i%size]
+		}
+	}
+	return vars, positions
+}
+
+// mapVars returns the variables of the cells of a grid
+func mapVars(cells, vars []int) []int {
+	mapped := make([]int, len(cells))
+	for j, i := range cells {
+		mapped[j] = vars[i]
+	}
+	return mapped
+}
+
+// gridPropagator runs a propagator of a single grid on the variables of a gattai model
+type gridPropagator struct {
+	p Propagator
+	// vars are the variables of all cells of the grid
+	vars  []int
+	scope []int
+}
+
+// Scope returns the variables of the scope of the grid's propagator
+func (p *gridPropagator) Scope() []int {
+	return p.scope
+}
+
+// Propagate runs the grid's propagator on the values and domains of its scope
+func (p *gridPropagator) Propagate(values []int, domains []Candidates) bool {
+	gridValues, gridDomains := make([]int, len(p.vars)), make([]Candidates, len(p.vars))
+	for _, i := range p.p.Scope() {
+		gridValues[i], gridDomains[i] = values[p.vars[i]], domains[p.vars[i]]
+	}
+	ok := p.p.Propagate(gridValues, gridDomains)
+	for _, i := range p.p.Scope() {
+		domains[p.vars[i]] = gridDomains[i]
+	}
+	return ok
+}
+
+// String turns object into human readable string
+// See Gattai.PrettyPrint
+func (p Gattai) String() string {
+	return p.PrettyPrint(nil)
+}
+
+// PrettyPrint prints the combined layout of all grids like Grid.PrettyPrint
+// Changes to the initial puzzle are printed in green.
+func (p Gattai) PrettyPrint(initial *Gattai) string {
+	width, height := p.Size()
+	// boxes are numbered by the first grid covering them
+	box := func(i int) int {
+		x, y := i%width, i/width
+		for j, part := range p.Parts {
+			if part.Covers(x, y) {
+				return j*MaxSize*MaxSize + part.Grid.box((y-part.Y)*part.Grid.Size+x-part.X)
+			}
+		}
+		return -1
+	}
+	noGroup := func(int) int { return -1 }
+	return drawOutline(width, height, box, noGroup, marks{}, func(i int) string {
+		x, y := i%width, i/width
+		num, _ := p.Get(x, y)
+		s := digit(num)
+		if initial != nil && num != EmptyCell {
+			if old, _ := initial.Get(x, y); old != num {
+				return color.HiGreenString(s)
+			}
+		}
+		return s
+	})
+}
@@ -0,0 +1,253 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testSamurai is a samurai sudoku with one row of the layout per line
+const testSamurai = `
+..6.9....   ..8..21..
+3..8.7...   .9....6.2
+.....5.79   ....9..8.
+78...4.3.   7......2.
+2........   1..4.73..
+...72....   6...2.4.8
+....4.....2.5.3..4...
+.9.1....58.3......5..
+..1....9.6..8........
+      3.49....6
+      .6...7..9
+      .....47..
+3..........2.........
+.6.21.......1.5.6.738
+4...............27.51
+.......1.   76.......
+..4.71...   ...7831..
+..8..42..   4....1...
+..1..3...   ...47.82.
+..7.9.1..   .....5...
+2...6..4.   ......67.
+`
+
+// testSamuraiSolved is the solution of testSamurai
+const testSamuraiSolved = `
+476291358   458672193
+359867421   397148652
+128435679   216395784
+785614932   784536921
+264359187   129487365
+913728564   635921478
+537942816429573264819
+692183745813962813547
+841576293675841759236
+      374958216
+      168237459
+      952164738
+372549681542397518462
+865217439786125964738
+419386527391684327951
+736825914   761249583
+924671358   952783146
+158934276   438651297
+641753892   513476829
+587492163   276895314
+293168745   849132675
+`
+
+// parseLayout sets the numbers of the rows in the puzzle, spaces are no cells
+func parseLayout(p *Gattai, layout string) *Gattai {
+	for y, row := range strings.Split(strings.Trim(layout, "\n"), "\n") {
+		for x, c := range row {
+			if c != ' ' {
+				num, _ := ParseDigit(c, 9)
+				p.Set(x, y, num)
+			}
+		}
+	}
+	return p
+}
+
+// printLayout returns the rows of the puzzle like in parseLayout
+func printLayout(p *Gattai) string {
+	width, height := p.Size()
+	b := strings.Builder{}
+	b.WriteString("\n")
+	for y := 0; y < height; y++ {
+		row := ""
+		for x := 0; x < width; x++ {
+			num, ok := p.Get(x, y)
+			switch {
+			case !ok:
+				row += " "
+			case num == EmptyCell:
+				row += "."
+			default:
+				row += digit(num)
+			}
+		}
+		b.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+	return b.String()
+}
+
+func TestSamurai(t *testing.T) {
+	p := Samurai()
+	if width, height := p.Size(); width != 21 || height != 21 {
+		t.Errorf("Gattai.Size() = %d, %d, want 21, 21", width, height)
+	}
+	if _, ok := p.Get(10, 2); ok {
+		t.Errorf("Gattai.Get() between the upper grids is a cell")
+	}
+	// the lower right box of the first grid is the upper left box of the center grid
+	p.Set(7, 8, 5)
+	if got := p.Parts[2].Grid.Get(1, 2); got != 5 {
+		t.Errorf("Gattai.Set() of shared cell set %d in center grid, want 5", got)
+	}
+	if got := p.EmptyCells(); got != 5*81-4*9-1 {
+		t.Errorf("Gattai.EmptyCells() = %d, want %d", got, 5*81-4*9-1)
+	}
+}
+
+func TestGattai_Check(t *testing.T) {
+	valid := parseLayout(Samurai(), testSamuraiSolved)
+
+	// a number is repeated in a row of the center grid
+	invalid := valid.Clone()
+	invalid.Set(9, 10, 6)
+
+	// the shared cells differ if they are not set through the layout
+	differing := Samurai()
+	differing.Parts[0].Grid.Set(8, 8, 3)
+
+	tests := []struct {
+		name     string
+		p        *Gattai
+		wantType ErrorType
+		wantErr  bool
+	}{
+		{name: "solved", p: valid},
+		{name: "empty", p: Samurai()},
+		{name: "repeated number", p: invalid, wantType: Row, wantErr: true},
+		{name: "shared cell", p: differing, wantErr: true},
+		{name: "no grids", p: &Gattai{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Check()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Gattai.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var errSudoku ErrorSudoku
+			if tt.wantType != "" && (!errors.As(err, &errSudoku) || errSudoku.eType != tt.wantType) {
+				t.Errorf("Gattai.Check() error = %v, want error in %s", err, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSolveGattai(t *testing.T) {
+	p := parseLayout(Samurai(), testSamurai)
+	steps := 0
+	got, err := SolveGattai(*p, func(updated Gattai, s Step) {
+		if num, _ := updated.Get(s.X, s.Y); num != s.Num {
+			t.Errorf("step %v is not set in puzzle", s)
+		}
+		steps++
+	})
+	if err != nil {
+		t.Fatalf("SolveGattai() error = %v", err)
+	}
+	if printLayout(got) != testSamuraiSolved {
+		t.Errorf("SolveGattai() = %s, want %s", printLayout(got), testSamuraiSolved)
+	}
+	if steps != p.EmptyCells() {
+		t.Errorf("SolveGattai() made %d steps, want %d", steps, p.EmptyCells())
+	}
+	if printLayout(p) != testSamurai {
+		t.Errorf("SolveGattai() changed the puzzle")
+	}
+
+	// the center grid alone cannot be solved
+	center := &Gattai{Parts: []Part{{X: 6, Y: 6, Grid: p.Parts[2].Grid}}}
+	if _, err := SolveGattai(*center, nil); err != ErrStuck {
+		t.Errorf("SolveGattai() of center grid error = %v, want %v", err, ErrStuck)
+	}
+}
+
+func TestGattai_PrettyPrint(t *testing.T) {
+	// two 4x4 grids sharing a box
+	p := &Gattai{Parts: []Part{
+		{Grid: NewGridBoxes(2, 2)},
+		{X: 2, Y: 2, Grid: NewGridBoxes(2, 2)},
+	}}
+	p.Set(0, 0, 1)
+	p.Set(3, 3, 2)
+	want := `
+┏━━━┯━━━┳━━━┯━━━┓        
+┃ 1 │   ┃   │   ┃        
+┠───┼───╂───┼───┨        
+┃   │   ┃   │   ┃        
+┣━━━┿━━━╋━━━┿━━━╋━━━┯━━━┓
+┃   │   ┃   │   ┃   │   ┃
+┠───┼───╂───┼───╂───┼───┨
+┃   │   ┃   │ 2 ┃   │   ┃
+┗━━━┷━━━╋━━━┿━━━╋━━━┿━━━┫
+        ┃   │   ┃   │   ┃
+        ┠───┼───╂───┼───┨
+        ┃   │   ┃   │   ┃
+        ┗━━━┷━━━┻━━━┷━━━┛`
+	if got := p.PrettyPrint(nil); got != want {
+		t.Errorf("Gattai.PrettyPrint() = %v, want %v", got, want)
+	}
+}
+
+func TestGattai_Model(t *testing.T) {
+	// two 4x4 grids sharing a box
+	p := &Gattai{Parts: []Part{
+		{Grid: NewGridBoxes(2, 2)},
+		{X: 2, Y: 2, Grid: NewGridBoxes(2, 2)},
+	}}
+	p.Set(0, 2, 1)
+	p.Set(2, 5, 3)
+	m, positions := p.Model()
+	if len(positions) != 2*16-4 || len(m.Domains) != len(positions) {
+		t.Fatalf("Gattai.Model() has %d variables, want %d", len(positions), 2*16-4)
+	}
+	if want := 2 * 12; len(m.Propagators) != want {
+		t.Errorf("Gattai.Model() has %d propagators, want %d", len(m.Propagators), want)
+	}
+	if !m.Propagate() {
+		t.Fatalf("Model.Propagate() failed")
+	}
+	width, _ := p.Size()
+	for v, pos := range positions {
+		// the shared cell misses the 1 of the row in the first grid and the 3 of the column in the second
+		if want := Candidates(0).With(2).With(4); pos == 2*width+2 && m.Domains[v] != want {
+			t.Errorf("Gattai.Model() shared cell = %v, want %v", m.Domains[v], want)
+		}
+	}
+}
+
+func TestSolveGattaiModel(t *testing.T) {
+	p := parseLayout(Samurai(), testSamurai)
+	// the center grid alone gets the solver stuck, but has solutions
+	center := &Gattai{Parts: []Part{{X: 6, Y: 6, Grid: p.Parts[2].Grid}}}
+	got, err := SolveGattaiModel(context.Background(), *center)
+	if err != nil {
+		t.Fatalf("SolveGattaiModel() error = %v", err)
+	}
+	if err := got.Check(); err != nil || got.EmptyCells() != 0 {
+		t.Errorf("SolveGattaiModel() = %v is not solved: %v", got, err)
+	}
+
+	invalid := p.Clone()
+	invalid.Set(9, 10, 6)
+	invalid.Set(10, 10, 6)
+	if _, err := SolveGattaiModel(context.Background(), *invalid); err == nil {
+		t.Errorf("SolveGattaiModel() error = nil for invalid puzzle")
+	}
+}
@@ -0,0 +1,60 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// maxAttempts is the number of sudokus generated to find one of the requested difficulty
+// Most generated sudokus need more than singles, about one in fifty is easy.
+var maxAttempts = 1000
+
+// Generate creates a random sudoku with a unique solution
+// If difficulty is not zero, sudokus are generated until one has this rating,
+// the context is done or maxAttempts sudokus were rated.
+func Generate(ctx context.Context, rng *rand.Rand, difficulty Difficulty) (*Field, error) {
+	if _, ok := difficultyNames[difficulty]; difficulty != 0 && !ok {
+		return nil, fmt.Errorf("Unknown difficulty %d", int(difficulty))
+	}
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		f, err := generate(ctx, rng)
+		if err != nil {
+			return nil, err
+		}
+		if difficulty == 0 {
+			return f, nil
+		}
+		if rating, _ := Rate(*f); rating == difficulty {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("No %s sudoku found in %d attempts", difficulty, maxAttempts)
+}
+
+// generate fills a random field and removes as many cells as possible,
+// while the solution stays unique
+func generate(ctx context.Context, rng *rand.Rand) (*Field, error) {
+	g := Field{}.Grid()
+	if err := g.Model().search(ctx, rng, func(values []int) bool {
+		copy(g.Cells, values)
+		return false
+	}); err != nil {
+		return nil, err
+	}
+	f, _ := g.Field()
+
+	for _, cell := range rng.Perm(9 * 9) {
+		x, y := cell%9, cell/9
+		num := f[y][x]
+		f[y][x] = EmptyCell
+		count, err := CountSolutions(ctx, *f, 2)
+		if err != nil {
+			return nil, err
+		}
+		if count != 1 {
+			f[y][x] = num
+		}
+	}
+	return f, nil
+}
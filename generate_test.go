@@ -0,0 +1,54 @@
+package sudoku
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty Difficulty
+	}{
+		{"any difficulty", 0},
+		{"easy", Easy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			got, err := Generate(ctx, rand.New(rand.NewSource(1)), tt.difficulty)
+			if err != nil {
+				t.Errorf("Generate() error = %v", err)
+				return
+			}
+			if count, _ := CountSolutions(ctx, *got, 2); count != 1 {
+				t.Errorf("Generate() = %v has %d solutions, want 1", got, count)
+			}
+			if rating, _ := Rate(*got); tt.difficulty != 0 && rating != tt.difficulty {
+				t.Errorf("Generate() has rating %v, want %v", rating, tt.difficulty)
+			}
+		})
+	}
+}
+
+func TestGenerate_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Generate(ctx, rand.New(rand.NewSource(1)), Easy); err == nil {
+		t.Errorf("Generate() error = nil for canceled context")
+	}
+}
+
+func TestGenerate_Attempts(t *testing.T) {
+	defer func(attempts int) { maxAttempts = attempts }(maxAttempts)
+	maxAttempts = 0
+	if _, err := Generate(context.Background(), rand.New(rand.NewSource(1)), Easy); err == nil {
+		t.Errorf("Generate() error = nil without attempts left")
+	}
+	if _, err := Generate(context.Background(), rand.New(rand.NewSource(1)), Difficulty(42)); err == nil {
+		t.Errorf("Generate() error = nil for unknown difficulty")
+	}
+}
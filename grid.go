@@ -0,0 +1,527 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// digits are the characters of the numbers in a grid, 10 is written as 'A'
+const digits = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// MaxSize is the largest supported grid size
+const MaxSize = len(digits)
+
+// Candidates is the set of possible numbers of a cell in a grid
+// Bit n-1 is set if the number n is possible.
+type Candidates uint64
+
+// AllCandidates returns a set with all numbers from 1 to size
+func AllCandidates(size int) Candidates {
+	return Candidates(1)<<uint(size) - 1
+}
+
+// Has checks if the number n is possible
+func (c Candidates) Has(n int) bool {
+	return c&(1<<uint(n-1)) != 0
+}
+
+// With returns the set with the number n
+func (c Candidates) With(n int) Candidates {
+	return c | 1<<uint(n-1)
+}
+
+// Without returns the set without the number n
+func (c Candidates) Without(n int) Candidates {
+	return c &^ (1 << uint(n-1))
+}
+
+// Count returns the number of possible numbers
+func (c Candidates) Count() int {
+	return bits.OnesCount64(uint64(c))
+}
+
+// Single returns the only possible number
+func (c Candidates) Single() (int, bool) {
+	if c.Count() != 1 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(uint64(c)) + 1, true
+}
+
+// Numbers lists all possible numbers in ascending order
+func (c Candidates) Numbers() []int {
+	var nums []int
+	for c != 0 {
+		n := bits.TrailingZeros64(uint64(c))
+		nums = append(nums, n+1)
+		c &^= 1 << uint(n)
+	}
+	return nums
+}
+
+// String prints the numbers as list, e.g. [4,5,8]
+func (c Candidates) String() string {
+	var nums []string
+	for _, n := range c.Numbers() {
+		nums = append(nums, fmt.Sprint(n))
+	}
+	return "[" + strings.Join(nums, ",") + "]"
+}
+
+// Grid is a sudoku of any size with rectangular boxes or irregular regions
+// A grid of Size n holds the numbers 1 to n in n rows and n columns.
+// The boxes are BoxWidth cells wide and BoxHeight cells high,
+// e.g. a 6x6 grid has six boxes of 3x2 cells.
+// In a jigsaw sudoku the boxes are replaced by the Regions.
+type Grid struct {
+	Size      int
+	BoxWidth  int
+	BoxHeight int
+	// Cells holds the numbers row by row, 0 means a cell is empty
+	Cells []int
+	// Regions holds the region of every cell row by row, numbered from 0 to Size-1
+	// Every region has Size connected cells. The grid has boxes if Regions is nil.
+	Regions []int
+	// Extra are additional houses of variants, e.g. the Diagonals of a Sudoku-X
+	Extra []House
+	// Constraints are the additional rules of a variant
+	Constraints []Constraint
+	// Marks are the pencil marks of every cell, e.g. with manual eliminations
+	// The solver only considers marked numbers, all numbers are marked if Marks is nil.
+	Marks []Candidates
+}
+
+// BoxSize returns the usual box width and height for a grid size
+// Boxes are as square as possible and wider than high,
+// e.g. 3x2 for 6x6 or 4x3 for 12x12.
+func BoxSize(size int) (width, height int, err error) {
+	if size < 1 || size > MaxSize {
+		return 0, 0, fmt.Errorf("Grid size %d is not between 1 and %d", size, MaxSize)
+	}
+	for h := 1; h*h <= size; h++ {
+		if size%h == 0 {
+			height = h
+		}
+	}
+	return size / height, height, nil
+}
+
+// NewGrid creates an empty grid with the usual box size, see BoxSize
+func NewGrid(size int) (*Grid, error) {
+	width, height, err := BoxSize(size)
+	if err != nil {
+		return nil, err
+	}
+	return NewGridBoxes(width, height), nil
+}
+
+// NewGridBoxes creates an empty grid with boxes of width x height cells
+func NewGridBoxes(width, height int) *Grid {
+	size := width * height
+	return &Grid{
+		Size:      size,
+		BoxWidth:  width,
+		BoxHeight: height,
+		Cells:     make([]int, size*size),
+	}
+}
+
+// Grid converts the field into a 9x9 grid
+func (f Field) Grid() *Grid {
+	g := NewGridBoxes(3, 3)
+	for i := 0; i < 9; i++ {
+		copy(g.Cells[i*9:], f[i][:])
+	}
+	return g
+}
+
+// gridOf converts the field into a grid, nil stays nil
+func gridOf(f *Field) *Grid {
+	if f == nil {
+		return nil
+	}
+	return f.Grid()
+}
+
+// Field converts a 9x9 grid with 3x3 boxes into a field
+// Grids of variants cannot be converted.
+func (g *Grid) Field() (*Field, error) {
+	if g.Size != 9 || g.BoxWidth != 3 {
+		return nil, fmt.Errorf("Grid of size %d with %dx%d boxes is no 9x9 field", g.Size, g.BoxWidth, g.BoxHeight)
+	}
+	if g.Regions != nil || len(g.Extra) > 0 {
+		return nil, fmt.Errorf("Grid with regions or extra houses is no classic field")
+	}
+	if len(g.Constraints) > 0 {
+		return nil, fmt.Errorf("Grid with %d constraints is no classic field", len(g.Constraints))
+	}
+	var f Field
+	for i := 0; i < 9; i++ {
+		copy(f[i][:], g.Cells[i*9:])
+	}
+	return &f, nil
+}
+
+// Clone returns a copy of the grid
+func (g *Grid) Clone() *Grid {
+	c := *g
+	c.Cells = append([]int(nil), g.Cells...)
+	if g.Marks != nil {
+		c.Marks = append([]Candidates(nil), g.Marks...)
+	}
+	return &c
+}
+
+// Get returns the number at (x,y)
+func (g *Grid) Get(x, y int) int {
+	return g.Cells[y*g.Size+x]
+}
+
+// Set sets the number at (x,y)
+func (g *Grid) Set(x, y, num int) {
+	g.Cells[y*g.Size+x] = num
+}
+
+// EmptyCells returns the count of empty cells
+func (g *Grid) EmptyCells() int {
+	var empty int
+	for _, num := range g.Cells {
+		if num == EmptyCell {
+			empty++
+		}
+	}
+	return empty
+}
+
+// House is a group of cells which must contain every number once
+type House struct {
+	// Type is reported as error type if a number is present more than once
+	Type ErrorType `json:"type"`
+	// Cells are the indices of the cells in Grid.Cells
+	Cells []int `json:"cells"`
+}
+
+// Houses returns all rows, columns and boxes of the grid followed by the extra houses
+// Jigsaw sudokus have their regions instead of the boxes.
+func (g *Grid) Houses() []House {
+	n := g.Size
+	houses := make([]House, 0, 3*n+len(g.Extra))
+	for i := 0; i < n; i++ {
+		row := House{Type: Row}
+		for j := 0; j < n; j++ {
+			row.Cells = append(row.Cells, i*n+j)
+		}
+		houses = append(houses, row)
+	}
+	for i := 0; i < n; i++ {
+		col := House{Type: Column}
+		for j := 0; j < n; j++ {
+			col.Cells = append(col.Cells, j*n+i)
+		}
+		houses = append(houses, col)
+	}
+	if g.Regions != nil {
+		regions := make([]House, n)
+		for i, r := range g.Regions {
+			regions[r].Type = Region
+			regions[r].Cells = append(regions[r].Cells, i)
+		}
+		houses = append(houses, regions...)
+		return append(houses, g.Extra...)
+	}
+	for by := 0; by < n; by += g.BoxHeight {
+		for bx := 0; bx < n; bx += g.BoxWidth {
+			box := House{Type: Square}
+			for y := by; y < by+g.BoxHeight; y++ {
+				for x := bx; x < bx+g.BoxWidth; x++ {
+					box.Cells = append(box.Cells, y*n+x)
+				}
+			}
+			houses = append(houses, box)
+		}
+	}
+	return append(houses, g.Extra...)
+}
+
+// Check validates if all cells are filled according to the sudoku rules
+// Regions of jigsaw sudokus are validated by CheckRegions.
+func (g *Grid) Check() error {
+	if (g.Regions == nil && g.BoxWidth*g.BoxHeight != g.Size) || len(g.Cells) != g.Size*g.Size {
+		return fmt.Errorf("Grid of size %d with %dx%d boxes and %d cells is malformed",
+			g.Size, g.BoxWidth, g.BoxHeight, len(g.Cells))
+	}
+	if g.Regions != nil {
+		if err := CheckRegions(g.Size, g.Regions); err != nil {
+			return err
+		}
+	}
+	if err := g.checkExtra(); err != nil {
+		return err
+	}
+	if g.Marks != nil && len(g.Marks) != len(g.Cells) {
+		return fmt.Errorf("Grid has %d pencil marks instead of %d", len(g.Marks), len(g.Cells))
+	}
+	for i, num := range g.Cells {
+		if num < 0 || num > g.Size {
+			return fmt.Errorf("Number %d at (%d,%d) is not between 1 and %d", num, i%g.Size, i/g.Size, g.Size)
+		}
+	}
+	for _, h := range g.Houses() {
+		var seen Candidates
+		for _, i := range h.Cells {
+			num := g.Cells[i]
+			if num == EmptyCell {
+				continue
+			}
+			if seen.Has(num) {
+				return ErrorSudoku{
+					num:   num,
+					x:     i % g.Size,
+					y:     i / g.Size,
+					eType: h.Type,
+				}
+			}
+			seen = seen.With(num)
+		}
+	}
+	for _, c := range g.Constraints {
+		if err := c.Check(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Conflicts marks all cells whose number is present more than once
+// in one of their houses, which are the cells Check complains about
+func (g *Grid) Conflicts() []bool {
+	marked := make([]bool, len(g.Cells))
+	for _, h := range g.Houses() {
+		count := make([]int, g.Size+1)
+		for _, i := range h.Cells {
+			count[g.Cells[i]]++
+		}
+		for _, i := range h.Cells {
+			if g.Cells[i] != EmptyCell && count[g.Cells[i]] > 1 {
+				marked[i] = true
+			}
+		}
+	}
+	return marked
+}
+
+// CheckRegions validates the regions of a jigsaw sudoku of size n
+// There must be n regions numbered from 0 to n-1, each with n connected cells.
+func CheckRegions(n int, regions []int) error {
+	if len(regions) != n*n {
+		return fmt.Errorf("Regions have %d instead of %d cells", len(regions), n*n)
+	}
+	count := make([]int, n)
+	for i, r := range regions {
+		if r < 0 || r >= n {
+			return fmt.Errorf("Region %d at (%d,%d) is not between 0 and %d", r, i%n, i/n, n-1)
+		}
+		count[r]++
+	}
+	for r, c := range count {
+		if c != n {
+			return fmt.Errorf("Region %d has %d instead of %d cells", r, c, n)
+		}
+	}
+
+	// every region is flooded from its first cell
+	reached := make([]bool, len(regions))
+	for start, r := range regions {
+		if reached[start] {
+			continue
+		}
+		size := 0
+		queue := []int{start}
+		reached[start] = true
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			size++
+			x, y := i%n, i/n
+			for _, next := range [][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				j := next[1]*n + next[0]
+				if next[0] >= 0 && next[0] < n && next[1] >= 0 && next[1] < n && !reached[j] && regions[j] == r {
+					reached[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+		if size != n {
+			return fmt.Errorf("Region %d at (%d,%d) is not connected", r, start%n, start/n)
+		}
+	}
+	return nil
+}
+
+// Candidates returns the possible numbers of all cells
+// Set cells have no candidates, empty cells only the marked numbers.
+// The candidates are pruned by all houses and constraints until none of them removes a candidate, see Grid.Model.
+func (g *Grid) Candidates() []Candidates {
+	m := g.Model()
+	m.Propagate()
+	return m.Domains
+}
+
+// digit returns the character of a number, see ParseDigit
+func digit(num int) string {
+	if num == EmptyCell {
+		return " "
+	}
+	return digits[num-1 : num]
+}
+
+// String turns object into human readable string
+// See Grid.PrettyPrint
+func (g Grid) String() string {
+	return g.PrettyPrint(nil)
+}
+
+// PrettyPrint turns the grid into a human readable string like Field.PrettyPrint
+// Numbers above 9 are printed as letters, changes to the initial grid are printed in green
+// e.g.:
+// ╔═════╦═════╗
+// ║1│ │ ║ │5│ ║
+// ║ │ │4║1│ │ ║
+// ╠═════╬═════╣
+// ║ │1│ ║ │ │3║
+// ║ │5│ ║ │ │ ║
+// ╠═════╬═════╣
+// ║ │ │ ║2│ │ ║
+// ║ │ │ ║ │1│6║
+// ╚═════╩═════╝
+func (g Grid) PrettyPrint(initial *Grid) string {
+	return g.PrettyPrintFunc(func(x, y int, num string) string {
+		if initial != nil && g.Get(x, y) != EmptyCell && g.Get(x, y) != initial.Get(x, y) {
+			return color.HiGreenString(num)
+		}
+		return num
+	})
+}
+
+// PrettyPrintFunc prints the grid in the same layout as PrettyPrint,
+// but every cell is passed to format before it is printed
+// Killer sudokus are printed with the outlines and sums of their cages,
+// jigsaw sudokus with the outlines of their regions, the cells of extra houses in brackets
+// and clues between adjacent cells on their border, see Grid.outline.
+func (g Grid) PrettyPrintFunc(format CellFormatter) string {
+	group := func(int) int { return -1 }
+	m := marks{bracketed: g.extraCells()}
+	for _, c := range g.Constraints {
+		if e, ok := c.(*Edges); ok {
+			m.edges = e.symbols(&g)
+		}
+		if k, ok := c.(*Killer); ok {
+			cageOf := make([]int, len(g.Cells))
+			for i := range cageOf {
+				cageOf[i] = -1
+			}
+			for j, c := range k.Cages {
+				for _, i := range c.Cells {
+					cageOf[i] = j
+				}
+			}
+			group = func(i int) int { return cageOf[i] }
+			m.labels = k.cageLabels(&g)
+		}
+	}
+	if m.labels != nil || m.edges != nil || m.bracketed != nil || g.Regions != nil {
+		return g.outline(g.box, group, m, format)
+	}
+
+	n := g.Size
+	border := func(left, middle, right string) string {
+		box := strings.Repeat("═", 2*g.BoxWidth-1)
+		return left + strings.Repeat(box+middle, n/g.BoxWidth-1) + box + right + "\n"
+	}
+
+	b := strings.Builder{}
+	b.WriteString("\n")
+	b.WriteString(border("╔", "╦", "╗"))
+	for i := 0; i < n; i++ {
+		b.WriteString("║")
+		for j := 0; j < n; j++ {
+			num := digit(g.Get(j, i))
+			if format != nil {
+				num = format(j, i, num)
+			}
+			b.WriteString(num)
+			if j < n-1 {
+				if (j+1)%g.BoxWidth == 0 {
+					b.WriteString("║")
+				} else {
+					b.WriteString("│")
+				}
+			}
+		}
+		b.WriteString("║\n")
+		if (i+1)%g.BoxHeight == 0 && i != n-1 {
+			b.WriteString(border("╠", "╬", "╣"))
+		}
+	}
+	out := border("╚", "╩", "╝")
+	b.WriteString(out[:len(out)-1])
+	return b.String()
+}
+
+// ParseGrid reads a grid from a single line with one character per cell
+// The size of the grid is the square root of the line length, the boxes are chosen by BoxSize.
+// The numbers 10 to 35 are written as letters 'A' to 'Z', '.' or '0' mean a cell is empty.
+// e.g. a 4x4 grid: 1..2.3.....1.4..
+func ParseGrid(s string) (*Grid, error) {
+	s = strings.TrimSpace(s)
+	size := 0
+	for size*size < len(s) {
+		size++
+	}
+	if size*size != len(s) {
+		return nil, fmt.Errorf("Line has %d characters, which is no square number", len(s))
+	}
+	g, err := NewGrid(size)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range strings.ToUpper(s) {
+		num, err := ParseDigit(c, size)
+		if err != nil {
+			return nil, fmt.Errorf("%s at position %d", err, i+1)
+		}
+		g.Cells[i] = num
+	}
+	return g, nil
+}
+
+// ParseDigit reads the number of a cell in a grid of size
+// The numbers 10 to 35 are the letters 'A' to 'Z', '.' or '0' mean a cell is empty.
+func ParseDigit(c rune, size int) (int, error) {
+	if c == '.' || c == '0' {
+		return EmptyCell, nil
+	}
+	num := strings.IndexRune(digits, c) + 1
+	if num < 1 || num > size {
+		return 0, fmt.Errorf("Invalid character '%c'", c)
+	}
+	return num, nil
+}
+
+// Line turns the grid into a single line with one character per cell
+// Empty cells are written as '.'
+// See ParseGrid
+func (g Grid) Line() string {
+	b := make([]byte, len(g.Cells))
+	for i, num := range g.Cells {
+		if num == EmptyCell {
+			b[i] = '.'
+		} else {
+			b[i] = digits[num-1]
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,386 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// patternGrid returns a solved grid with boxes of width x height cells
+func patternGrid(width, height int) *Grid {
+	g := NewGridBoxes(width, height)
+	for y := 0; y < g.Size; y++ {
+		for x := 0; x < g.Size; x++ {
+			g.Set(x, y, ((y%height)*width+y/height+x)%g.Size+1)
+		}
+	}
+	return g
+}
+
+// removeCells empties every n-th cell of the grid
+func removeCells(g *Grid, n int) *Grid {
+	removed := g.Clone()
+	for i := range removed.Cells {
+		if i%n == 0 {
+			removed.Cells[i] = EmptyCell
+		}
+	}
+	return removed
+}
+
+func TestBoxSize(t *testing.T) {
+	tests := []struct {
+		size       int
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{size: 4, wantWidth: 2, wantHeight: 2},
+		{size: 6, wantWidth: 3, wantHeight: 2},
+		{size: 9, wantWidth: 3, wantHeight: 3},
+		{size: 12, wantWidth: 4, wantHeight: 3},
+		{size: 16, wantWidth: 4, wantHeight: 4},
+		{size: 25, wantWidth: 5, wantHeight: 5},
+		{size: 7, wantWidth: 7, wantHeight: 1},
+		{size: 0, wantErr: true},
+		{size: 36, wantErr: true},
+	}
+	for _, tt := range tests {
+		width, height, err := BoxSize(tt.size)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("BoxSize(%d) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+			continue
+		}
+		if width != tt.wantWidth || height != tt.wantHeight {
+			t.Errorf("BoxSize(%d) = %d, %d, want %d, %d", tt.size, width, height, tt.wantWidth, tt.wantHeight)
+		}
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	c := AllCandidates(16).Without(3).Without(16)
+	if c.Has(3) || c.Has(16) || !c.Has(1) || !c.Has(15) {
+		t.Errorf("Candidates %v has wrong numbers", c)
+	}
+	if c.Count() != 14 {
+		t.Errorf("Candidates.Count() = %d, want 14", c.Count())
+	}
+	if num, ok := AllCandidates(12).Without(12).Single(); ok {
+		t.Errorf("Candidates.Single() = %d, want none", num)
+	}
+	if num, ok := Candidates(0).Single(); ok {
+		t.Errorf("Candidates.Single() of empty set = %d, want none", num)
+	}
+	if num, ok := Candidates(1 << 11).Single(); !ok || num != 12 {
+		t.Errorf("Candidates.Single() = %d, %v, want 12, true", num, ok)
+	}
+	if got := AllCandidates(5).Without(2).String(); got != "[1,3,4,5]" {
+		t.Errorf("Candidates.String() = %s, want [1,3,4,5]", got)
+	}
+}
+
+func TestGrid_Field(t *testing.T) {
+	g := testField.Grid()
+	if g.Size != 9 || g.BoxWidth != 3 || g.BoxHeight != 3 {
+		t.Fatalf("Field.Grid() has size %d with %dx%d boxes", g.Size, g.BoxWidth, g.BoxHeight)
+	}
+	if g.Line() != testField.Line() {
+		t.Errorf("Field.Grid() = %s, want %s", g.Line(), testField.Line())
+	}
+	f, err := g.Field()
+	if err != nil || !reflect.DeepEqual(f, testField) {
+		t.Errorf("Grid.Field() = %v, %v, want %v", f, err, testField)
+	}
+	if _, err := NewGridBoxes(2, 2).Field(); err == nil {
+		t.Errorf("Grid.Field() of 4x4 grid returned no error")
+	}
+}
+
+func TestParseGrid(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantSize   int
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{
+			name:       "4x4",
+			line:       "1..2.3.....1.4..",
+			wantSize:   4,
+			wantWidth:  2,
+			wantHeight: 2,
+		},
+		{
+			name:       "6x6",
+			line:       patternGrid(3, 2).Line(),
+			wantSize:   6,
+			wantWidth:  3,
+			wantHeight: 2,
+		},
+		{
+			name:       "16x16",
+			line:       removeCells(patternGrid(4, 4), 3).Line(),
+			wantSize:   16,
+			wantWidth:  4,
+			wantHeight: 4,
+		},
+		{
+			name:    "no square",
+			line:    "1..2.3",
+			wantErr: true,
+		},
+		{
+			name:    "number too large",
+			line:    "1..5.3.....1.4..",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGrid(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseGrid() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Size != tt.wantSize || got.BoxWidth != tt.wantWidth || got.BoxHeight != tt.wantHeight {
+				t.Errorf("ParseGrid() has size %d with %dx%d boxes, want %d with %dx%d",
+					got.Size, got.BoxWidth, got.BoxHeight, tt.wantSize, tt.wantWidth, tt.wantHeight)
+			}
+			if got.Line() != tt.line {
+				t.Errorf("Grid.Line() = %s, want %s", got.Line(), tt.line)
+			}
+		})
+	}
+}
+
+func TestGrid_Check(t *testing.T) {
+	// the 3x2 boxes are violated by columns of the wrong box height
+	wrongBoxes := patternGrid(2, 3)
+	wrongBoxes.BoxWidth, wrongBoxes.BoxHeight = 3, 2
+
+	row := patternGrid(3, 2)
+	row.Set(1, 0, 1)
+	// swapping two cells in a box keeps the rows and boxes valid
+	column := patternGrid(3, 2)
+	column.Set(0, 0, 2)
+	column.Set(1, 0, 1)
+
+	tests := []struct {
+		name     string
+		g        *Grid
+		wantType ErrorType
+		wantErr  bool
+	}{
+		{name: "6x6", g: patternGrid(3, 2)},
+		{name: "12x12", g: patternGrid(4, 3)},
+		{name: "25x25", g: patternGrid(5, 5)},
+		{name: "row", g: row, wantType: Row, wantErr: true},
+		{name: "column", g: column, wantType: Column, wantErr: true},
+		{name: "box", g: wrongBoxes, wantType: Square, wantErr: true},
+		{name: "malformed", g: &Grid{Size: 4, BoxWidth: 2, BoxHeight: 2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.g.Check()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Grid.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var errSudoku ErrorSudoku
+			if tt.wantType != "" && (!errors.As(err, &errSudoku) || errSudoku.eType != tt.wantType) {
+				t.Errorf("Grid.Check() error = %v, want error in %s", err, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSolveGrid(t *testing.T) {
+	tests := []struct {
+		name string
+		want *Grid
+		// every n-th cell is removed, n must not divide the size
+		n int
+	}{
+		{name: "4x4", want: patternGrid(2, 2), n: 3},
+		{name: "6x6", want: patternGrid(3, 2), n: 5},
+		{name: "9x9", want: patternGrid(3, 3), n: 2},
+		{name: "12x12", want: patternGrid(4, 3), n: 5},
+		{name: "16x16", want: patternGrid(4, 4), n: 3},
+		{name: "25x25", want: patternGrid(5, 5), n: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := removeCells(tt.want, tt.n)
+			steps := 0
+			got, err := SolveGrid(*g, func(updated Grid, s Step) {
+				if updated.Get(s.X, s.Y) != s.Num {
+					t.Errorf("step %v is not set in grid", s)
+				}
+				steps++
+			})
+			if err != nil {
+				t.Errorf("SolveGrid() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SolveGrid() = %v, want %v", got, tt.want)
+			}
+			if steps != g.EmptyCells() {
+				t.Errorf("SolveGrid() made %d steps, want %d", steps, g.EmptyCells())
+			}
+		})
+	}
+}
+
+func TestGrid_Candidates_Marks(t *testing.T) {
+	g := testField.Grid()
+	if got, want := g.Candidates()[1], Candidates(0).With(2).With(5).With(6); got != want {
+		t.Errorf("Grid.Candidates() = %v, want %v", got, want)
+	}
+
+	// the solver only considers marked numbers
+	g.Marks = g.Candidates()
+	g.Marks[1] = Candidates(0).With(2)
+	var first *Step
+	SolveGrid(*g, func(updated Grid, s Step) {
+		if first == nil {
+			first = &s
+		}
+	})
+	if want := (Step{X: 1, Y: 0, Num: 2, Strategy: NakedSingle}); first == nil || *first != want {
+		t.Errorf("SolveGrid() first step = %v, want %v", first, want)
+	}
+}
+
+func TestGrid_PrettyPrint(t *testing.T) {
+	g, _ := ParseGrid("1..2.3.....1.4..")
+	want := `
+╔═══╦═══╗
+║1│ ║ │2║
+║ │3║ │ ║
+╠═══╬═══╣
+║ │ ║ │1║
+║ │4║ │ ║
+╚═══╩═══╝`
+	if got := g.PrettyPrint(nil); got != want {
+		t.Errorf("Grid.PrettyPrint() = %v, want %v", got, want)
+	}
+}
+
+// testRegions are the regions of a 9x9 jigsaw sudoku
+const testRegions = "" +
+	"AAAABBCCC" +
+	"AABBBBCCC" +
+	"AADBBECCF" +
+	"ADDBEECFF" +
+	"DDDEEEEFF" +
+	"DDDEEFFFF" +
+	"GGGHHHIII" +
+	"GGHHHHIII" +
+	"GGGGHHIII"
+
+// jigsaw returns the grid of the line with regions given by one letter per cell
+func jigsaw(line, regions string) *Grid {
+	g, _ := ParseGrid(line)
+	g.Regions = make([]int, len(regions))
+	for i, c := range regions {
+		g.Regions[i] = int(c - 'A')
+	}
+	return g
+}
+
+func TestCheckRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions string
+		wantErr bool
+	}{
+		{name: "jigsaw", regions: testRegions},
+		{name: "boxes", regions: "AABBAABBCCDDCCDD"},
+		{name: "too few cells", regions: "AABBAABBCCDDCCD", wantErr: true},
+		{name: "region too large", regions: "AABBAABBCADDCCDD", wantErr: true},
+		{name: "unknown region", regions: "AABBAABBCCDDCCDE", wantErr: true},
+		{name: "not connected", regions: "ABBBAABCCCDDCDDA", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regions := make([]int, len(tt.regions))
+			for i, c := range tt.regions {
+				regions[i] = int(c - 'A')
+			}
+			n := 4
+			if len(regions) == 81 {
+				n = 9
+			}
+			if err := CheckRegions(n, regions); (err != nil) != tt.wantErr {
+				t.Errorf("CheckRegions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrid_Check_Jigsaw(t *testing.T) {
+	// the numbers are valid in the boxes, but not in the regions
+	g := jigsaw("1234341221434321", "AABBACBBACDDCCDD")
+	var errSudoku ErrorSudoku
+	if err := g.Check(); !errors.As(err, &errSudoku) || errSudoku.eType != Region {
+		t.Errorf("Grid.Check() error = %v, want error in region", err)
+	}
+	if _, err := g.Field(); err == nil {
+		t.Errorf("Grid.Field() of jigsaw returned no error")
+	}
+}
+
+func TestSolveGrid_Jigsaw(t *testing.T) {
+	g := jigsaw("9..5....8....5...4.....8.........9...17...4.....2..69......3..9....7.3...3..8....", testRegions)
+	want := "963542178781956234429738561142867953617395482358214697574623819895471326236189745"
+
+	regionSteps := 0
+	got, err := SolveGrid(*g, func(updated Grid, s Step) {
+		if s.Strategy == HiddenSingleRegion {
+			regionSteps++
+		}
+	})
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	if got.Line() != want {
+		t.Errorf("SolveGrid() = %s, want %s", got.Line(), want)
+	}
+	if regionSteps == 0 {
+		t.Errorf("SolveGrid() found no hidden single in a region")
+	}
+}
+
+func TestGrid_PrettyPrint_Jigsaw(t *testing.T) {
+	g := jigsaw("1...............", "AABBACBBACDDCCDD")
+	want := `
+┏━━━┯━━━┳━━━┯━━━┓
+┃ 1 │   ┃   │   ┃
+┠───╆━━━╉───┼───┨
+┃   ┃   ┃   │   ┃
+┠───╂───╊━━━┿━━━┫
+┃   ┃   ┃   │   ┃
+┣━━━╃───╂───┼───┨
+┃   │   ┃   │   ┃
+┗━━━┷━━━┻━━━┷━━━┛`
+	if got := g.PrettyPrint(nil); got != want {
+		t.Errorf("Grid.PrettyPrint() = %v, want %v", got, want)
+	}
+}
+
+func TestGrid_Conflicts(t *testing.T) {
+	g := patternGrid(2, 2)
+	g.Cells[1] = g.Cells[0]
+	want := make([]bool, len(g.Cells))
+	// row and box of the first cell and the column of the second cell
+	want[0], want[1], want[13] = true, true, true
+	if got := g.Conflicts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Grid.Conflicts() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HTMLStyle is the CSS for tables returned by Grid.HTMLTable
+// Thick borders separate the boxes or regions, changes to the initial grid are green
+const HTMLStyle = `table.sudoku{border-collapse:collapse;border:3px solid #000}
+table.sudoku td{width:2em;height:2em;padding:0;border:1px solid #000;text-align:center;vertical-align:middle;font:1.4em sans-serif}
+table.sudoku td.solved{color:#1a9f1a}
+table.sudoku+table.sudoku{margin-top:2em}
+`
+
+// HTMLTable turns the field into a HTML table
+// See Grid.HTMLTable
+func (f Field) HTMLTable(initial *Field) string {
+	return f.Grid().HTMLTable(gridOf(initial))
+}
+
+// HTML turns the field into a standalone HTML document
+// See Grid.HTMLTable
+func (f Field) HTML(initial *Field) string {
+	return f.Grid().HTML(gridOf(initial))
+}
+
+// HTMLTable turns the grid into a HTML table, which is styled by HTMLStyle
+// Changes to the initial grid have the class "solved",
+// the thick borders between boxes or regions are set on the cells.
+func (g *Grid) HTMLTable(initial *Grid) string {
+	b := strings.Builder{}
+	b.WriteString("<table class=\"sudoku\">\n")
+	for y := 0; y < g.Size; y++ {
+		b.WriteString("<tr>")
+		for x := 0; x < g.Size; x++ {
+			i := y*g.Size + x
+			b.WriteString("<td")
+			if num := g.Cells[i]; num != EmptyCell && initial != nil && num != initial.Cells[i] {
+				b.WriteString(` class="solved"`)
+			}
+			right, bottom := g.borders(i)
+			switch {
+			case right && bottom:
+				b.WriteString(` style="border-right-width:3px;border-bottom-width:3px"`)
+			case right:
+				b.WriteString(` style="border-right-width:3px"`)
+			case bottom:
+				b.WriteString(` style="border-bottom-width:3px"`)
+			}
+			if num := g.Cells[i]; num != EmptyCell {
+				fmt.Fprintf(&b, ">%s</td>", digit(num))
+			} else {
+				b.WriteString("></td>")
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
+
+// HTML turns the grid into a standalone HTML document
+// See Grid.HTMLTable
+func (g *Grid) HTML(initial *Grid) string {
+	return HTMLDocument(g.HTMLTable(initial))
+}
+
+// HTMLDocument turns tables of Grid.HTMLTable into a standalone HTML document styled by HTMLStyle
+// The tables are placed below each other.
+func HTMLDocument(tables ...string) string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Sudoku</title>\n<style>\n" +
+		HTMLStyle + "</style>\n</head>\n<body>\n" + strings.Join(tables, "") + "</body>\n</html>\n"
+}
@@ -0,0 +1,139 @@
+package sudoku
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestField_HTMLTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       Field
+		initial *Field
+		given   int
+		solved  int
+	}{
+		{
+			name:    "empty field",
+			f:       Field{},
+			initial: nil,
+		},
+		{
+			name:    "givens",
+			f:       *testField,
+			initial: testField,
+			given:   23,
+		},
+		{
+			name:    "solved field",
+			f:       *testFieldSolved,
+			initial: testField,
+			given:   23,
+			solved:  9*9 - 23,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.f.HTMLTable(tt.initial)
+
+			// the table is valid XML as well
+			var table struct {
+				Rows []struct {
+					Cells []struct {
+						Class string `xml:"class,attr"`
+						Num   string `xml:",chardata"`
+					} `xml:"td"`
+				} `xml:"tr"`
+			}
+			if err := xml.Unmarshal([]byte(got), &table); err != nil {
+				t.Errorf("Field.HTMLTable() returned invalid table: %v", err)
+				return
+			}
+			given, solved := 0, 0
+			for i, row := range table.Rows {
+				if len(row.Cells) != 9 {
+					t.Errorf("Field.HTMLTable() row %d has %d cells", i, len(row.Cells))
+				}
+				for _, cell := range row.Cells {
+					switch {
+					case cell.Class == "solved":
+						solved++
+					case cell.Num != "":
+						given++
+					}
+				}
+			}
+			if len(table.Rows) != 9 || given != tt.given || solved != tt.solved {
+				t.Errorf("Field.HTMLTable() has %d rows, %d given and %d solved cells, want 9, %d and %d",
+					len(table.Rows), given, solved, tt.given, tt.solved)
+			}
+		})
+	}
+}
+
+func TestField_HTML(t *testing.T) {
+	got := testField.HTML(nil)
+	for _, want := range []string{"<!DOCTYPE html>", HTMLStyle, "<td>7</td>", "</html>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Field.HTML() = %v, does not contain %v", got, want)
+		}
+	}
+}
+
+func TestGrid_HTMLTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		g        *Grid
+		initial  *Grid
+		contains []string
+	}{
+		{
+			name: "6x6 grid",
+			g:    patternGrid(3, 2),
+			contains: []string{
+				`<tr><td>1</td><td>2</td><td style="border-right-width:3px">3</td><td>4</td>`,
+				`<td style="border-right-width:3px;border-bottom-width:3px">`,
+			},
+		},
+		{
+			name:     "letters",
+			g:        patternGrid(4, 4),
+			initial:  removeCells(patternGrid(4, 4), 2),
+			contains: []string{`<td class="solved">1</td>`, `<td>G</td>`},
+		},
+		{
+			name:     "jigsaw",
+			g:        jigsaw(strings.Repeat(".", 16), "AABBACBBACDDCCDD"),
+			contains: []string{`<tr><td></td><td style="border-right-width:3px"></td><td></td><td></td></tr>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.g.HTMLTable(tt.initial)
+			if err := xml.Unmarshal([]byte(got), new(interface{})); err != nil {
+				t.Errorf("Grid.HTMLTable() returned invalid table: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Grid.HTMLTable() = %v, does not contain %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTMLDocument(t *testing.T) {
+	got := HTMLDocument(testField.HTMLTable(nil), patternGrid(3, 2).HTMLTable(nil))
+	if n := strings.Count(got, "<html>"); n != 1 {
+		t.Errorf("HTMLDocument() has %d documents, want 1", n)
+	}
+	if n := strings.Count(got, `<table class="sudoku">`); n != 2 {
+		t.Errorf("HTMLDocument() has %d tables, want 2", n)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", HTMLStyle, "<td>7</td>", "<td>6</td>", "</body>\n</html>\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTMLDocument() = %v, does not contain %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON turns the field into a string in line format
+// See Field.Line
+func (f Field) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Line())
+}
+
+// UnmarshalJSON reads the field either from a string in line format
+// or from an array of 9 rows with 9 numbers each
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		field, err := ParseLine(line)
+		if err != nil {
+			return err
+		}
+		*f = *field
+		return nil
+	}
+
+	var rows [][]int
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	if len(rows) != 9 {
+		return fmt.Errorf("Field has %d rows instead of 9", len(rows))
+	}
+	var field Field
+	for i, row := range rows {
+		if len(row) != 9 {
+			return fmt.Errorf("Row %d has %d cells instead of 9", i, len(row))
+		}
+		for j, num := range row {
+			if num < 0 || num > 9 {
+				return fmt.Errorf("Cell (%d,%d) has invalid number %d", j, i, num)
+			}
+			field[i][j] = num
+		}
+	}
+	*f = field
+	return nil
+}
+
+// MarshalJSON turns the grid into a string in line format
+// Only the numbers are written, the layout is chosen by ParseGrid when reading it.
+// See Grid.Line
+func (g Grid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Line())
+}
+
+// UnmarshalJSON reads the grid either from a string in line format
+// or from an array of n rows with n numbers each
+func (g *Grid) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		grid, err := ParseGrid(line)
+		if err != nil {
+			return err
+		}
+		*g = *grid
+		return nil
+	}
+
+	var rows [][]int
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	grid, err := NewGrid(len(rows))
+	if err != nil {
+		return err
+	}
+	for i, row := range rows {
+		if len(row) != grid.Size {
+			return fmt.Errorf("Row %d has %d cells instead of %d", i, len(row), grid.Size)
+		}
+		for j, num := range row {
+			if num < 0 || num > grid.Size {
+				return fmt.Errorf("Cell (%d,%d) has invalid number %d", j, i, num)
+			}
+			grid.Set(j, i, num)
+		}
+	}
+	*g = *grid
+	return nil
+}
+
+// MarshalJSON turns the candidates into a list of all possible numbers
+// e.g. [4,5,8]
+func (c Candidates) MarshalJSON() ([]byte, error) {
+	nums := c.Numbers()
+	if nums == nil {
+		nums = []int{}
+	}
+	return json.Marshal(nums)
+}
+
+// UnmarshalJSON reads candidates from a list of all possible numbers
+func (c *Candidates) UnmarshalJSON(data []byte) error {
+	var nums []int
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return err
+	}
+	var cands Candidates
+	for _, n := range nums {
+		if n < 1 || n > MaxSize {
+			return fmt.Errorf("Invalid possible number %d", n)
+		}
+		cands = cands.With(n)
+	}
+	*c = cands
+	return nil
+}
+
+// MarshalJSON turns possibilities into a list of all possible numbers
+// e.g. [4,5,8]
+func (p Possibilities) MarshalJSON() ([]byte, error) {
+	nums := []int{}
+	for n, v := range p {
+		if v {
+			nums = append(nums, n+1)
+		}
+	}
+	return json.Marshal(nums)
+}
+
+// UnmarshalJSON reads possibilities from a list of all possible numbers
+func (p *Possibilities) UnmarshalJSON(data []byte) error {
+	var nums []int
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return err
+	}
+	var pos Possibilities
+	for _, n := range nums {
+		if n < 1 || n > len(pos) {
+			return fmt.Errorf("Invalid possible number %d", n)
+		}
+		pos.Add(n)
+	}
+	*p = pos
+	return nil
+}
+
+// MarshalJSON turns the object into 9 rows with the possible numbers of 9 cells each
+// Missing possibilities are written as empty list
+func (c SolverField) MarshalJSON() ([]byte, error) {
+	var rows [9][9]Possibilities
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			if c[i][j] != nil {
+				rows[i][j] = *c[i][j]
+			}
+		}
+	}
+	return json.Marshal(rows)
+}
+
+// UnmarshalJSON reads 9 rows with the possible numbers of 9 cells each
+func (c *SolverField) UnmarshalJSON(data []byte) error {
+	var rows [][]Possibilities
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	if len(rows) != 9 {
+		return fmt.Errorf("Possibilities have %d rows instead of 9", len(rows))
+	}
+	var check SolverField
+	for i, row := range rows {
+		if len(row) != 9 {
+			return fmt.Errorf("Row %d has %d cells instead of 9", i, len(row))
+		}
+		for j := range row {
+			check[i][j] = &row[j]
+		}
+	}
+	*c = check
+	return nil
+}
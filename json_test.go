@@ -0,0 +1,173 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestField_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Field
+		wantErr bool
+	}{
+		{
+			name:    "line",
+			data:    `"` + testFieldLine + `"`,
+			want:    testField,
+			wantErr: false,
+		},
+		{
+			name: "rows",
+			data: `[[7,0,0,0,0,4,8,0,0],[0,0,0,0,0,5,4,0,0],[0,0,9,0,0,0,7,0,0],
+				[4,0,0,0,0,0,0,9,0],[8,0,7,0,0,0,0,0,0],[0,0,0,6,1,0,0,0,0],
+				[0,3,0,0,5,0,0,0,1],[0,1,0,2,0,0,0,7,5],[0,0,0,1,4,3,0,0,0]]`,
+			want:    testField,
+			wantErr: false,
+		},
+		{
+			name:    "too little rows",
+			data:    `[[7,0,0,0,0,4,8,0,0]]`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid number",
+			data:    `[[10,0,0,0,0,4,8,0,0],[],[],[],[],[],[],[],[]]`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid line",
+			data:    `"7....48"`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Field
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Field.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want != nil && !reflect.DeepEqual(&got, tt.want) {
+				t.Errorf("Field.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal(testField)
+	if err != nil {
+		t.Errorf("Field.MarshalJSON() error = %v", err)
+		return
+	}
+	if want := `"` + testFieldLine + `"`; string(got) != want {
+		t.Errorf("Field.MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestPossibilities_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Possibilities
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			p:    Possibilities{},
+			want: "[]",
+		},
+		{
+			name: "not empty",
+			p:    Possibilities{true, false, false, true},
+			want: "[1,4]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.p)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Possibilities.MarshalJSON() = %s, %v, want %v", got, err, tt.want)
+				return
+			}
+			var p Possibilities
+			if err := json.Unmarshal(got, &p); err != nil || p != tt.p {
+				t.Errorf("Possibilities.UnmarshalJSON() = %v, %v, want %v", p, err, tt.p)
+			}
+		})
+	}
+
+	var p Possibilities
+	if err := json.Unmarshal([]byte("[0]"), &p); err == nil {
+		t.Errorf("Possibilities.UnmarshalJSON() error = nil for invalid number")
+	}
+}
+
+func TestSolverField_JSON(t *testing.T) {
+	check := NewSolverField(testField)
+	check[0][1].Remove(6)
+	check[8][8] = nil
+
+	data, err := json.Marshal(check)
+	if err != nil {
+		t.Errorf("SolverField.MarshalJSON() error = %v", err)
+		return
+	}
+	var got SolverField
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Errorf("SolverField.UnmarshalJSON() error = %v", err)
+		return
+	}
+	// missing possibilities are read as empty
+	check[8][8] = new(Possibilities)
+	if !reflect.DeepEqual(&got, check) {
+		t.Errorf("SolverField.UnmarshalJSON() = %v, want %v", got, check)
+	}
+
+	if err := json.Unmarshal([]byte("[[[1]]]"), &got); err == nil {
+		t.Errorf("SolverField.UnmarshalJSON() error = nil for too little rows")
+	}
+}
+
+func TestGrid_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "line", data: `"1..2.3.....1.4.."`, want: "1..2.3.....1.4.."},
+		{name: "rows", data: `[[1,0,0,2],[0,3,0,0],[0,0,0,1],[0,4,0,0]]`, want: "1..2.3.....1.4.."},
+		{name: "letters", data: `"` + patternGrid(4, 4).Line() + `"`, want: patternGrid(4, 4).Line()},
+		{name: "no square", data: `"123"`, wantErr: true},
+		{name: "short row", data: `[[1,0,0,2],[0,3,0],[0,0,0,1],[0,4,0,0]]`, wantErr: true},
+		{name: "invalid number", data: `[[1,0,0,5],[0,3,0,0],[0,0,0,1],[0,4,0,0]]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Grid
+			err := json.Unmarshal([]byte(tt.data), &g)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Grid.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if g.Line() != tt.want {
+				t.Errorf("Grid.UnmarshalJSON() = %v, want %v", g.Line(), tt.want)
+			}
+			data, err := json.Marshal(g)
+			if want := `"` + tt.want + `"`; err != nil || string(data) != want {
+				t.Errorf("Grid.MarshalJSON() = %s, %v, want %s", data, err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Cage is a group of cells whose numbers add up to Sum without repeating a number
+type Cage struct {
+	Sum int
+	// Cells are the indices of the cells in Grid.Cells
+	Cells []int
+}
+
+// Killer is the rule of a killer sudoku: the numbers in every cage add up to its sum
+type Killer struct {
+	Cages []Cage
+}
+
+// Check validates that no cage repeats a number or exceeds its sum
+// The sum of a cage is only compared when all of its cells are set.
+func (k *Killer) Check(g *Grid) error {
+	for _, c := range k.Cages {
+		var seen Candidates
+		sum, empty := 0, 0
+		for _, i := range c.Cells {
+			num := g.Cells[i]
+			if num == EmptyCell {
+				empty++
+				continue
+			}
+			if seen.Has(num) {
+				return ErrorSudoku{
+					num:   num,
+					x:     i % g.Size,
+					y:     i / g.Size,
+					eType: KillerCage,
+				}
+			}
+			seen = seen.With(num)
+			sum += num
+		}
+		switch {
+		case empty == 0 && sum != c.Sum:
+			return newErrorConstraint(g, CageSum, c.Cells, "numbers add up to %d instead of %d", sum, c.Sum)
+		case empty > 0 && sum+empty > c.Sum:
+			return newErrorConstraint(g, CageSum, c.Cells, "numbers already add up to %d of %d", sum, c.Sum)
+		}
+	}
+	return nil
+}
+
+// Propagators returns every cage of the puzzle and the cages derived by the 45 rule, see Killer.derived
+func (k *Killer) Propagators(g *Grid) []Propagator {
+	var props []Propagator
+	for _, cages := range [][]Cage{k.Cages, k.derived(g)} {
+		for i := range cages {
+			props = append(props, &cages[i])
+		}
+	}
+	return props
+}
+
+// Scope returns the cells of the cage
+func (c *Cage) Scope() []int {
+	return c.Cells
+}
+
+// Propagate restricts the candidates of the cells to all combinations of different numbers adding up to the sum
+// The combinations are enumerated cell by cell as the sets of numbers used so far,
+// the sum of a set is the sum of its numbers.
+func (c *Cage) Propagate(values []int, cands []Candidates) bool {
+	n := len(c.Cells)
+	options := make([]Candidates, n)
+	for j, i := range c.Cells {
+		options[j] = value(values, cands, i)
+	}
+
+	// all sets of numbers which can be placed in the first j cells
+	reachable := make([]map[Candidates]bool, n+1)
+	reachable[0] = map[Candidates]bool{0: true}
+	for j := 0; j < n; j++ {
+		reachable[j+1] = make(map[Candidates]bool)
+		for used := range reachable[j] {
+			for _, num := range (options[j] &^ used).Numbers() {
+				reachable[j+1][used.With(num)] = true
+			}
+		}
+	}
+
+	// walk back from the sets with the right sum and keep the numbers leading there
+	valid := make(map[Candidates]bool)
+	for used := range reachable[n] {
+		if used.sum() == c.Sum {
+			valid[used] = true
+		}
+	}
+	allowed := make([]Candidates, n)
+	for j := n - 1; j >= 0; j-- {
+		prev := make(map[Candidates]bool)
+		for used := range reachable[j] {
+			for _, num := range (options[j] &^ used).Numbers() {
+				if valid[used.With(num)] {
+					prev[used] = true
+					allowed[j] = allowed[j].With(num)
+				}
+			}
+		}
+		valid = prev
+	}
+
+	for j, i := range c.Cells {
+		restrict(values, cands, i, allowed[j])
+	}
+	return len(valid) > 0
+}
+
+// sum adds up all numbers in the set
+func (c Candidates) sum() int {
+	sum := 0
+	for c != 0 {
+		n := bits.TrailingZeros64(uint64(c))
+		sum += n + 1
+		c &^= 1 << uint(n)
+	}
+	return sum
+}
+
+// derived returns the cages which follow from the 45 rule
+// Every house adds up to 1+2+...+n, which is 45 in a 9x9 grid, so
+// the cells of a house outside the cages lying completely in it form a cage (innies) and
+// a single cell sticking out of the cages covering a house is the difference to the house sum (outie).
+func (k *Killer) derived(g *Grid) []Cage {
+	total := g.Size * (g.Size + 1) / 2
+	cageOf := make([]int, len(g.Cells))
+	for i := range cageOf {
+		cageOf[i] = -1
+	}
+	for j, c := range k.Cages {
+		for _, i := range c.Cells {
+			cageOf[i] = j
+		}
+	}
+
+	var derived []Cage
+	for _, h := range g.Houses() {
+		inHouse := make(map[int]bool, len(h.Cells))
+		for _, i := range h.Cells {
+			inHouse[i] = true
+		}
+		// cages overlapping the house in order of their first cell in it
+		var overlapping []int
+		seen := make(map[int]bool)
+		covered := true
+		for _, i := range h.Cells {
+			if j := cageOf[i]; j == -1 {
+				covered = false
+			} else if !seen[j] {
+				seen[j] = true
+				overlapping = append(overlapping, j)
+			}
+		}
+
+		inner, overlap := 0, 0
+		inside := make(map[int]bool)
+		var outies []int
+		for _, j := range overlapping {
+			overlap += k.Cages[j].Sum
+			in := true
+			for _, i := range k.Cages[j].Cells {
+				if !inHouse[i] {
+					in = false
+					outies = append(outies, i)
+				}
+			}
+			if in {
+				inside[j] = true
+				inner += k.Cages[j].Sum
+			}
+		}
+
+		var innies []int
+		for _, i := range h.Cells {
+			if j := cageOf[i]; j == -1 || !inside[j] {
+				innies = append(innies, i)
+			}
+		}
+		// a whole house as cage adds nothing to the sudoku rules
+		if len(innies) > 0 && len(innies) < g.Size {
+			derived = append(derived, Cage{Sum: total - inner, Cells: innies})
+		}
+		if covered && len(outies) == 1 {
+			derived = append(derived, Cage{Sum: overlap - total, Cells: outies})
+		}
+	}
+	return derived
+}
+
+// cageLabels returns the sum of every cage at its first cell
+func (k *Killer) cageLabels(g *Grid) []string {
+	labels := make([]string, len(g.Cells))
+	for _, c := range k.Cages {
+		first := c.Cells[0]
+		for _, i := range c.Cells {
+			if i < first {
+				first = i
+			}
+		}
+		labels[first] = fmt.Sprint(c.Sum)
+	}
+	return labels
+}
@@ -0,0 +1,192 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testKiller returns a killer sudoku with horizontal cages of two cells
+// and single cells in the last column, the sums are taken from testFieldSolved
+func testKiller() *Killer {
+	solved := testFieldSolved.Grid()
+	k := &Killer{}
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x += 2 {
+			c := Cage{Cells: []int{y*9 + x}}
+			if x < 8 {
+				c.Cells = append(c.Cells, y*9+x+1)
+			}
+			for _, i := range c.Cells {
+				c.Sum += solved.Cells[i]
+			}
+			k.Cages = append(k.Cages, c)
+		}
+	}
+	return k
+}
+
+func TestKiller_Check(t *testing.T) {
+	k := &Killer{Cages: []Cage{{Sum: 10, Cells: []int{0, 1, 9}}}}
+
+	tests := []struct {
+		name     string
+		cells    map[int]int
+		wantType ErrorType
+	}{
+		{name: "empty cage", cells: nil},
+		{name: "partial cage", cells: map[int]int{0: 3, 1: 5}},
+		{name: "complete cage", cells: map[int]int{0: 3, 1: 5, 9: 2}},
+		{name: "repeated number", cells: map[int]int{0: 3, 9: 3}, wantType: KillerCage},
+		{name: "wrong sum", cells: map[int]int{0: 3, 1: 5, 9: 1}, wantType: CageSum},
+		{name: "sum exceeded", cells: map[int]int{0: 4, 1: 6}, wantType: CageSum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			for i, num := range tt.cells {
+				g.Cells[i] = num
+			}
+			err := k.Check(g)
+			var errSudoku ErrorSudoku
+			var errConstraint ErrorConstraint
+			switch {
+			case tt.wantType == "" && err != nil:
+				t.Errorf("Killer.Check() error = %v, want nil", err)
+			case tt.wantType == KillerCage && (!errors.As(err, &errSudoku) || errSudoku.eType != KillerCage):
+				t.Errorf("Killer.Check() error = %v, want repeated number in cage", err)
+			case tt.wantType == CageSum && (!errors.As(err, &errConstraint) || errConstraint.Type() != CageSum):
+				t.Errorf("Killer.Check() error = %v, want wrong cage sum", err)
+			case tt.wantType == CageSum && !reflect.DeepEqual(errConstraint.Cells(), [][2]int{{0, 0}, {1, 0}, {0, 1}}):
+				t.Errorf("Killer.Check() error has cells %v, want the cells of the cage", errConstraint.Cells())
+			}
+		})
+	}
+}
+
+func TestCage_Propagate(t *testing.T) {
+	all := AllCandidates(9)
+	tests := []struct {
+		name  string
+		cage  Cage
+		set   map[int]int
+		cands map[int]Candidates
+		want  map[int]Candidates
+	}{
+		{
+			name: "only combination",
+			cage: Cage{Sum: 3, Cells: []int{0, 1}},
+			want: map[int]Candidates{0: 0b11, 1: 0b11},
+		},
+		{
+			name: "largest numbers",
+			cage: Cage{Sum: 24, Cells: []int{0, 1, 2}},
+			want: map[int]Candidates{0: 0b111000000, 1: 0b111000000, 2: 0b111000000},
+		},
+		{
+			name: "set cell",
+			cage: Cage{Sum: 10, Cells: []int{0, 1}},
+			set:  map[int]int{0: 3},
+			want: map[int]Candidates{1: Candidates(0).With(7)},
+		},
+		{
+			name:  "combination must fit the candidates",
+			cage:  Cage{Sum: 10, Cells: []int{0, 1, 2}},
+			cands: map[int]Candidates{0: Candidates(0).With(1).With(6)},
+			// 1+2+7, 1+3+6, 1+4+5 or 6+1+3
+			want: map[int]Candidates{
+				0: Candidates(0).With(1).With(6),
+				1: Candidates(0).With(1).With(2).With(3).With(4).With(5).With(6).With(7),
+				2: Candidates(0).With(1).With(2).With(3).With(4).With(5).With(6).With(7),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			cands := make([]Candidates, len(g.Cells))
+			for i := range cands {
+				cands[i] = all
+			}
+			for i, num := range tt.set {
+				g.Cells[i], cands[i] = num, 0
+			}
+			for i, c := range tt.cands {
+				cands[i] = c
+			}
+			if !tt.cage.Propagate(g.Cells, cands) {
+				t.Fatalf("Cage.Propagate() failed")
+			}
+			for i, want := range tt.want {
+				if cands[i] != want {
+					t.Errorf("Cage.Propagate() cell %d = %v, want %v", i, cands[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestKiller_derived(t *testing.T) {
+	// the first row holds a cage of 8 cells, the last cell sticks out of the cage below
+	k := &Killer{Cages: []Cage{
+		{Sum: 40, Cells: []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{Sum: 12, Cells: []int{8, 17}},
+	}}
+	g := NewGridBoxes(3, 3)
+	derived := k.derived(g)
+
+	want := []Cage{
+		// innie of the first row
+		{Sum: 5, Cells: []int{8}},
+		// outie of the first row
+		{Sum: 7, Cells: []int{17}},
+	}
+	for _, c := range want {
+		found := false
+		for _, d := range derived {
+			if reflect.DeepEqual(c, d) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Killer.derived() = %v, does not contain %v", derived, c)
+		}
+	}
+}
+
+func TestSolveGrid_Killer(t *testing.T) {
+	g, _ := ParseGrid("7....48.......54....9...7..4......9..............................................")
+	g.Constraints = []Constraint{testKiller()}
+
+	got, err := SolveGrid(*g, nil)
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	want := testFieldSolved.Grid()
+	if !reflect.DeepEqual(got.Cells, want.Cells) {
+		t.Errorf("SolveGrid() = %v, want %v", got, want)
+	}
+}
+
+func TestGrid_PrettyPrint_Killer(t *testing.T) {
+	g, _ := ParseGrid("1...............")
+	g.Constraints = []Constraint{&Killer{Cages: []Cage{
+		{Sum: 3, Cells: []int{0, 1}},
+		{Sum: 7, Cells: []int{2, 3, 7}},
+		{Sum: 7, Cells: []int{4, 8, 9, 12, 13}},
+		{Sum: 9, Cells: []int{5, 6}},
+	}}}
+	want := `
+┏3━━━━━━┳7━━━━━━┓
+┃ 1     ┃       ┃
+┠7──┬9──╂───┐   ┃
+┃   │   ┃   │   ┃
+┣━━━┷━━━╋━━━┿━━━┫
+┃       ┃   │   ┃
+┃       ┠───┼───┨
+┃       ┃   │   ┃
+┗━━━━━━━┻━━━┷━━━┛`
+	if got := g.PrettyPrint(nil); got != want {
+		t.Errorf("Grid.PrettyPrint() = %v, want %v", got, want)
+	}
+}
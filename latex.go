@@ -0,0 +1,89 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LaTeX turns the field into a LaTeX tabular
+// See Grid.LaTeX
+func (f Field) LaTeX(initial *Field) string {
+	return f.Grid().LaTeX(gridOf(initial))
+}
+
+// TikZ turns the field into a tikzpicture
+// See Grid.TikZ
+func (f Field) TikZ(initial *Field) string {
+	return f.Grid().TikZ(gridOf(initial))
+}
+
+// LaTeX turns the grid into a LaTeX tabular
+// The boxes are separated by double lines like in PrettyPrint,
+// changes to the initial grid are printed in green which needs the xcolor package.
+// The regions of jigsaw sudokus cannot be drawn by a tabular, see Grid.TikZ.
+// e.g.:
+// \begin{tabular}{||c|c|c||c|c|c||c|c|c||}
+// \hline\hline
+// 7 & \phantom{0} & \phantom{0} & ...\\ \hline
+// ...
+// \end{tabular}
+func (g *Grid) LaTeX(initial *Grid) string {
+	n := g.Size
+	b := strings.Builder{}
+	b.WriteString("\\begin{tabular}{|")
+	for j := 0; j < n; j++ {
+		if g.Regions == nil && j%g.BoxWidth == 0 {
+			b.WriteString("|")
+		}
+		b.WriteString("c|")
+	}
+	b.WriteString("|}\n\\hline\\hline\n")
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if j > 0 {
+				b.WriteString(" & ")
+			}
+			b.WriteString(g.latexCell(initial, i*n+j))
+		}
+		b.WriteString(" \\\\ \\hline")
+		if (g.Regions == nil && (i+1)%g.BoxHeight == 0) || i == n-1 {
+			b.WriteString("\\hline")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\\end{tabular}\n")
+	return b.String()
+}
+
+// TikZ turns the grid into a tikzpicture with thick lines around the boxes or regions
+// Every cell is one unit wide, changes to the initial grid are printed in green
+func (g *Grid) TikZ(initial *Grid) string {
+	n := g.Size
+	b := strings.Builder{}
+	b.WriteString("\\begin{tikzpicture}\n")
+	fmt.Fprintf(&b, "\\draw[step=1] (0,0) grid (%d,%d);\n", n, n)
+	// rows are counted from the top, TikZ coordinates from the bottom
+	for _, l := range g.thickLines() {
+		fmt.Fprintf(&b, "\\draw[very thick] (%d,%d) -- (%d,%d);\n", l[0], n-l[1], l[2], n-l[3])
+	}
+	for i, num := range g.Cells {
+		if num != EmptyCell {
+			fmt.Fprintf(&b, "\\node at (%.1f,%.1f) {%s};\n", float64(i%n)+0.5, float64(n-i/n)-0.5, g.latexCell(initial, i))
+		}
+	}
+	b.WriteString("\\end{tikzpicture}\n")
+	return b.String()
+}
+
+// latexCell returns the content of the cell i
+// Empty cells are filled with a phantom digit, so all columns have the same width
+func (g *Grid) latexCell(initial *Grid, i int) string {
+	num := g.Cells[i]
+	switch {
+	case num == EmptyCell:
+		return "\\phantom{0}"
+	case initial != nil && num != initial.Cells[i]:
+		return fmt.Sprintf("\\textcolor{green!60!black}{%s}", digit(num))
+	}
+	return digit(num)
+}
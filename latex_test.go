@@ -0,0 +1,94 @@
+package sudoku
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestField_LaTeX(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       Field
+		initial *Field
+		lines   map[int]string
+	}{
+		{
+			name:    "empty field",
+			f:       Field{},
+			initial: nil,
+			lines: map[int]string{
+				0:  `\begin{tabular}{||c|c|c||c|c|c||c|c|c||}`,
+				1:  `\hline\hline`,
+				2:  `\phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} \\ \hline`,
+				4:  `\phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} & \phantom{0} \\ \hline\hline`,
+				11: `\end{tabular}`,
+			},
+		},
+		{
+			name:    "solved field",
+			f:       *testFieldSolved,
+			initial: testField,
+			lines: map[int]string{
+				2: `7 & \textcolor{green!60!black}{2} & \textcolor{green!60!black}{5} & \textcolor{green!60!black}{3} & \textcolor{green!60!black}{6} & 4 & 8 & \textcolor{green!60!black}{1} & \textcolor{green!60!black}{9} \\ \hline`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(tt.f.LaTeX(tt.initial), "\n")
+			for i, line := range tt.lines {
+				if got[i] != line {
+					t.Errorf("Field.LaTeX() line %d = %v, want %v", i, got[i], line)
+				}
+			}
+		})
+	}
+}
+
+func TestField_TikZ(t *testing.T) {
+	got := testFieldSolved.TikZ(testField)
+	for _, want := range []string{
+		"\\begin{tikzpicture}\n",
+		"\\node at (0.5,8.5) {7};\n",
+		"\\node at (1.5,8.5) {\\textcolor{green!60!black}{2}};\n",
+		"\\node at (8.5,0.5) {\\textcolor{green!60!black}{8}};\n",
+		"\\end{tikzpicture}\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Field.TikZ() = %v, does not contain %v", got, want)
+		}
+	}
+	if n := strings.Count(testField.TikZ(nil), "\\node"); n != 23 {
+		t.Errorf("Field.TikZ() has %d nodes, want 23", n)
+	}
+}
+
+func TestGrid_LaTeX(t *testing.T) {
+	got := strings.Split(patternGrid(3, 2).LaTeX(nil), "\n")
+	for i, line := range map[int]string{
+		0: `\begin{tabular}{||c|c|c||c|c|c||}`,
+		2: `1 & 2 & 3 & 4 & 5 & 6 \\ \hline`,
+		3: `4 & 5 & 6 & 1 & 2 & 3 \\ \hline\hline`,
+		7: `6 & 1 & 2 & 3 & 4 & 5 \\ \hline\hline`,
+	} {
+		if got[i] != line {
+			t.Errorf("Grid.LaTeX() line %d = %v, want %v", i, got[i], line)
+		}
+	}
+}
+
+func TestGrid_TikZ(t *testing.T) {
+	got := jigsaw("1..2.3.....1.4..", "AABBACBBACDDCCDD").TikZ(nil)
+	for _, want := range []string{
+		"\\draw[step=1] (0,0) grid (4,4);\n",
+		"\\draw[very thick] (1,3) -- (1,1);\n",
+		"\\node at (3.5,3.5) {2};\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Grid.TikZ() = %v, does not contain %v", got, want)
+		}
+	}
+	if n := strings.Count(got, "very thick"); n != 9 {
+		t.Errorf("Grid.TikZ() has %d thick lines, want 9", n)
+	}
+}
@@ -0,0 +1,48 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineLength is the number of characters of a field in line format
+const LineLength = 9 * 9
+
+// ParseLine reads a field from a single line of 81 characters
+// The cells are listed row by row, '.' or '0' mean a cell is empty
+// e.g.: 7....48.......54....9...7..4......9.8.7.........61.....3..5...1.1.2...75...143...
+func ParseLine(s string) (*Field, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != LineLength {
+		return nil, fmt.Errorf("Line has %d characters instead of %d", len(s), LineLength)
+	}
+	var field Field
+	for i, c := range s {
+		switch {
+		case c == '.' || c == '0':
+			field[i/9][i%9] = EmptyCell
+		case c >= '1' && c <= '9':
+			field[i/9][i%9] = int(c - '0')
+		default:
+			return nil, fmt.Errorf("Invalid character '%c' at position %d", c, i+1)
+		}
+	}
+	return &field, nil
+}
+
+// Line turns the field into a single line of 81 characters
+// Empty cells are written as '.'
+// See ParseLine
+func (f Field) Line() string {
+	b := make([]byte, 0, LineLength)
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			if f[i][j] == EmptyCell {
+				b = append(b, '.')
+			} else {
+				b = append(b, byte('0'+f[i][j]))
+			}
+		}
+	}
+	return string(b)
+}
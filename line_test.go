@@ -0,0 +1,91 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testFieldLine = "7....48.......54....9...7..4......9.8.7.........61.....3..5...1.1.2...75...143..."
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    *Field
+		wantErr bool
+	}{
+		{
+			name:    "dots",
+			s:       testFieldLine,
+			want:    testField,
+			wantErr: false,
+		},
+		{
+			name:    "zeros and surrounding spaces",
+			s:       " 700004800000005400009000700400000090807000000000610000030050001010200075000143000\r\n",
+			want:    testField,
+			wantErr: false,
+		},
+		{
+			name:    "too short",
+			s:       testFieldLine[1:],
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			s:       "x" + testFieldLine[1:],
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			s:       "",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLine(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_Line(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Field
+		want string
+	}{
+		{
+			name: "empty",
+			f:    Field{},
+			want: ".................................................................................",
+		},
+		{
+			name: "test field",
+			f:    *testField,
+			want: testFieldLine,
+		},
+		{
+			name: "solved field",
+			f:    *testFieldSolved,
+			want: "725364819183975462649821753461538297857492136392617584238759641914286375576143928",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.Line(); got != tt.want {
+				t.Errorf("Field.Line() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package sudoku
+
+// LittleKiller is a sum outside of the grid for the numbers along a diagonal
+type LittleKiller struct {
+	Sum int
+	// Cells are the indices of the cells in Grid.Cells along the diagonal
+	Cells []int
+}
+
+// LittleKillerCells returns the cells of the diagonal starting outside of the grid
+// at row and column, both counted from 0 so that -1 and g.Size are outside,
+// into the direction dRow and dCol of 1 or -1.
+// e.g. the diagonal of the clue left of the third row pointing up and right:
+// LittleKillerCells(g, 2, -1, -1, 1) == [9, 1]
+func LittleKillerCells(g *Grid, row, col, dRow, dCol int) []int {
+	var cells []int
+	for row, col = row+dRow, col+dCol; row >= 0 && row < g.Size && col >= 0 && col < g.Size; row, col = row+dRow, col+dCol {
+		cells = append(cells, row*g.Size+col)
+	}
+	return cells
+}
+
+// Check validates that the numbers along the diagonal add up to the sum, see setSum
+func (l *LittleKiller) Check(g *Grid) error {
+	sum, empty := setSum(g, l.Cells)
+	switch {
+	case empty == 0 && sum != l.Sum:
+		return newErrorConstraint(g, DiagonalSum, l.Cells, "numbers add up to %d instead of %d", sum, l.Sum)
+	case sum+empty > l.Sum:
+		return newErrorConstraint(g, DiagonalSum, l.Cells, "numbers already add up to %d of %d", sum, l.Sum)
+	}
+	return nil
+}
+
+// Propagators returns the diagonal as sum, see Sum
+func (l *LittleKiller) Propagators(g *Grid) []Propagator {
+	return []Propagator{&Sum{Total: l.Sum, Vars: l.Cells}}
+}
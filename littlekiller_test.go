@@ -0,0 +1,132 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLittleKillerCells(t *testing.T) {
+	g := NewGridBoxes(3, 3)
+	tests := []struct {
+		name       string
+		row, col   int
+		dRow, dCol int
+		want       []int
+	}{
+		{name: "above", row: -1, col: 2, dRow: 1, dCol: 1, want: []int{3, 13, 23, 33, 43, 53}},
+		{name: "left", row: 2, col: -1, dRow: -1, dCol: 1, want: []int{9, 1}},
+		{name: "below", row: 9, col: 7, dRow: -1, dCol: -1, want: []int{78, 68, 58, 48, 38, 28, 18}},
+		{name: "corner", row: 9, col: 9, dRow: -1, dCol: -1, want: []int{80, 70, 60, 50, 40, 30, 20, 10, 0}},
+		{name: "outside", row: -1, col: 0, dRow: 1, dCol: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LittleKillerCells(g, tt.row, tt.col, tt.dRow, tt.dCol); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LittleKillerCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLittleKiller_Check(t *testing.T) {
+	diagonal := &LittleKiller{Sum: 10, Cells: []int{2, 10, 18}}
+	corner := &LittleKiller{Sum: 5, Cells: []int{0}}
+	tests := []struct {
+		name      string
+		little    *LittleKiller
+		cells     map[int]int
+		wantCells [][2]int
+	}{
+		{name: "empty", little: diagonal},
+		{name: "sum", little: diagonal, cells: map[int]int{2: 3, 10: 3, 18: 4}},
+		{name: "partial", little: diagonal, cells: map[int]int{2: 3, 10: 5}},
+		{name: "cells off the diagonal", little: diagonal, cells: map[int]int{1: 9, 11: 9}},
+		{name: "wrong sum", little: diagonal, cells: map[int]int{2: 3, 10: 3, 18: 3}, wantCells: [][2]int{{2, 0}, {1, 1}, {0, 2}}},
+		// the empty cell needs at least 1
+		{name: "exceeded", little: diagonal, cells: map[int]int{2: 9, 10: 1}, wantCells: [][2]int{{2, 0}, {1, 1}, {0, 2}}},
+		{name: "single cell", little: corner, cells: map[int]int{0: 5}},
+		{name: "single cell wrong", little: corner, cells: map[int]int{0: 4}, wantCells: [][2]int{{0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkConstraintErr(t, "LittleKiller.Check()", tt.little.Check(testGrid(tt.cells)), DiagonalSum, tt.wantCells)
+		})
+	}
+}
+
+func TestLittleKiller_Propagators(t *testing.T) {
+	g := NewGridBoxes(3, 3)
+	tests := []struct {
+		name   string
+		cells  map[int]int
+		little *LittleKiller
+		// want are the candidates of all cells of the diagonal
+		want []Candidates
+		ok   bool
+	}{
+		{
+			name:   "set cell",
+			cells:  map[int]int{10: 2},
+			little: &LittleKiller{Sum: 6, Cells: []int{2, 10, 18}},
+			want:   []Candidates{between(1, 3), 0, between(1, 3)},
+			ok:     true,
+		},
+		{
+			// the sum alone allows repeated numbers, only the houses forbid them
+			name:   "repeated numbers",
+			little: &LittleKiller{Sum: 4, Cells: LittleKillerCells(g, 4, -1, -1, 1)},
+			want:   []Candidates{between(1, 1), between(1, 1), between(1, 1), between(1, 1)},
+			ok:     true,
+		},
+		{
+			name:   "single cell",
+			little: &LittleKiller{Sum: 7, Cells: LittleKillerCells(g, 9, 9, -1, -1)[:1]},
+			want:   []Candidates{between(7, 7)},
+			ok:     true,
+		},
+		{
+			name:   "sum too large",
+			little: &LittleKiller{Sum: 19, Cells: LittleKillerCells(g, -1, 6, 1, 1)},
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGrid(tt.cells)
+			cands := testCands(g)
+			if ok := propagateConstraint(g, tt.little, cands); ok != tt.ok {
+				t.Fatalf("LittleKiller.Propagators() ok = %v, want %v", ok, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			got := make([]Candidates, len(tt.little.Cells))
+			for j, i := range tt.little.Cells {
+				got[j] = cands[i]
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LittleKiller.Propagators() pruned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveGrid_LittleKiller(t *testing.T) {
+	solved := testFieldSolved.Grid()
+	g, _ := ParseGrid("7....................8..7.34......9....49..........5........641.........57.......")
+	// all clues above the grid with at least three cells
+	for col := 0; col < solved.Size; col++ {
+		for _, dCol := range []int{-1, 1} {
+			cells := LittleKillerCells(solved, -1, col, 1, dCol)
+			if len(cells) < 3 {
+				continue
+			}
+			little := &LittleKiller{Cells: cells}
+			for _, i := range cells {
+				little.Sum += solved.Cells[i]
+			}
+			g.Constraints = append(g.Constraints, little)
+		}
+	}
+	checkSolveGrid(t, g, solved)
+}
@@ -0,0 +1,165 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+)
+
+// Model is a constraint satisfaction problem over variables with a domain of numbers
+// A rule set is a list of propagators, e.g. a classic sudoku has a variable per cell
+// and an AllDifferent per row, column and box, see Grid.Model.
+type Model struct {
+	// Values are the numbers of all set variables, EmptyCell for open ones
+	// All variables are open if Values is nil.
+	Values []int
+	// Domains are the possible numbers of all open variables
+	Domains     []Candidates
+	Propagators []Propagator
+}
+
+// Propagator is a constraint between some variables of a model
+type Propagator interface {
+	// Scope returns the indices of the variables in Model.Domains the constraint depends on
+	Scope() []int
+
+	// Propagate removes all numbers from the domains of the open variables in its scope
+	// which cannot be part of a solution, set variables are only read from values.
+	// It returns false if the constraint cannot be satisfied anymore.
+	Propagate(values []int, domains []Candidates) bool
+}
+
+// NewModel creates a model of n open variables which can all hold the numbers from 1 to max
+func NewModel(n, max int) *Model {
+	m := &Model{Values: make([]int, n), Domains: make([]Candidates, n)}
+	for i := range m.Domains {
+		m.Values[i] = EmptyCell
+		m.Domains[i] = AllCandidates(max)
+	}
+	return m
+}
+
+// Add adds propagators to the rules of the model
+func (m *Model) Add(props ...Propagator) {
+	m.Propagators = append(m.Propagators, props...)
+}
+
+// Model turns the grid into a model with a variable per cell
+// Set cells are set variables, the domains of empty cells hold only their marked numbers.
+// Every house is an AllDifferent, every constraint of the variant adds its propagators.
+func (g *Grid) Model() *Model {
+	m := &Model{Values: append([]int(nil), g.Cells...), Domains: make([]Candidates, len(g.Cells))}
+	all := AllCandidates(g.Size)
+	for i, num := range g.Cells {
+		if num != EmptyCell {
+			continue
+		}
+		m.Domains[i] = all
+		if g.Marks != nil {
+			m.Domains[i] &= g.Marks[i]
+		}
+	}
+	for _, h := range g.Houses() {
+		m.Add(&AllDifferent{Vars: h.Cells})
+	}
+	for _, c := range g.Constraints {
+		m.Add(c.Propagators(g)...)
+	}
+	return m
+}
+
+// Propagate runs all propagators until none of them removes a number
+// It returns false if a propagator failed or the domain of an open variable became empty,
+// the domains are only partly pruned then.
+func (m *Model) Propagate() bool {
+	m.open()
+	all := make([]int, len(m.Propagators))
+	for p := range all {
+		all[p] = p
+	}
+	return m.propagate(m.Values, m.Domains, m.watchers(), all)
+}
+
+// Set sets the variable v to num and propagates the change like Propagate
+func (m *Model) Set(v, num int) bool {
+	m.open()
+	m.Values[v], m.Domains[v] = num, 0
+	watchers := m.watchers()
+	return m.propagate(m.Values, m.Domains, watchers, watchers[v])
+}
+
+// open sets all variables of a model without values open
+func (m *Model) open() {
+	if m.Values == nil {
+		m.Values = make([]int, len(m.Domains))
+	}
+}
+
+// watchers returns the indices of the propagators depending on every variable
+func (m *Model) watchers() [][]int {
+	watchers := make([][]int, len(m.Domains))
+	for p, prop := range m.Propagators {
+		for _, v := range prop.Scope() {
+			watchers[v] = append(watchers[v], p)
+		}
+	}
+	return watchers
+}
+
+// propagate runs the propagators in queue and all propagators depending on a changed domain
+// until none of them removes a number
+func (m *Model) propagate(values []int, domains []Candidates, watchers [][]int, queue []int) bool {
+	queued := make([]bool, len(m.Propagators))
+	queue = append([]int(nil), queue...)
+	for _, p := range queue {
+		queued[p] = true
+	}
+	var before []Candidates
+	for len(queue) > 0 {
+		p := queue[0]
+		queue, queued[p] = queue[1:], false
+
+		scope := m.Propagators[p].Scope()
+		before = before[:0]
+		for _, v := range scope {
+			before = append(before, domains[v])
+		}
+		if !m.Propagators[p].Propagate(values, domains) {
+			return false
+		}
+		for j, v := range scope {
+			if domains[v] == before[j] {
+				continue
+			}
+			if domains[v] == 0 {
+				return false
+			}
+			for _, q := range watchers[v] {
+				if !queued[q] {
+					queue, queued[q] = append(queue, q), true
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Search calls found with the numbers of all variables for every solution until it returns false
+// The open variable with the least possible numbers is set first, see search.
+func (m *Model) Search(ctx context.Context, found func(values []int) bool) error {
+	return m.search(ctx, nil, found)
+}
+
+// Solve returns the numbers of all variables of the first solution found by Search
+func (m *Model) Solve(ctx context.Context) ([]int, error) {
+	var solution []int
+	if err := m.Search(ctx, func(values []int) bool {
+		solution = values
+		return false
+	}); err != nil {
+		return nil, err
+	}
+	if solution == nil {
+		return nil, fmt.Errorf("model has no solution")
+	}
+	return solution, nil
+}
@@ -0,0 +1,123 @@
+package sudoku
+
+import (
+	"context"
+	"testing"
+)
+
+func TestModel_Search(t *testing.T) {
+	empty, _ := NewGrid(4)
+	tests := []struct {
+		name  string
+		m     *Model
+		limit int
+		want  int
+	}{
+		{name: "unique solution", m: testField.Grid().Model(), want: 1},
+		{name: "multiple solutions", m: testField2.Grid().Model(), limit: 10, want: 10},
+		{name: "all 4x4 grids", m: empty.Model(), want: 288},
+		{name: "magic squares", m: magicSquare(), want: 8},
+		{name: "no solution", m: &Model{
+			Domains:     []Candidates{between(1, 2), between(1, 2), between(1, 2)},
+			Propagators: []Propagator{&AllDifferent{Vars: []int{0, 1, 2}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			err := tt.m.Search(context.Background(), func(values []int) bool {
+				count++
+				return tt.limit < 1 || count < tt.limit
+			})
+			if err != nil {
+				t.Fatalf("Model.Search() error = %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("Model.Search() found %d solutions, want %d", count, tt.want)
+			}
+		})
+	}
+}
+
+// magicSquare is the model of a 3x3 square of the numbers 1 to 9
+// whose rows, columns and diagonals all add up to 15
+func magicSquare() *Model {
+	m := NewModel(9, 9)
+	m.Add(&AllDifferent{Vars: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}})
+	for _, vars := range [][]int{
+		{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+		{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+		{0, 4, 8}, {2, 4, 6},
+	} {
+		m.Add(&Sum{Total: 15, Vars: vars})
+	}
+	return m
+}
+
+func TestModel_Solve(t *testing.T) {
+	m := testField.Grid().Model()
+	values, err := m.Solve(context.Background())
+	if err != nil {
+		t.Fatalf("Model.Solve() error = %v", err)
+	}
+	got := testField.Grid()
+	copy(got.Cells, values)
+	if want := testFieldSolved.Grid(); got.Line() != want.Line() {
+		t.Errorf("Model.Solve() = %s, want %s", got.Line(), want.Line())
+	}
+
+	m.Add(&Relation{A: 0, B: 1, Holds: func(a, b int) bool { return a < b }})
+	if _, err := m.Solve(context.Background()); err == nil {
+		t.Errorf("Model.Solve() error = nil for model without solution")
+	}
+}
+
+func TestModel_Search_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g, _ := NewGrid(9)
+	err := g.Model().Search(ctx, func([]int) bool { return true })
+	if err != context.Canceled {
+		t.Errorf("Model.Search() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSolveGridModel(t *testing.T) {
+	solved := testFieldSolved.Grid()
+	tests := []struct {
+		name    string
+		g       *Grid
+		want    string
+		wantErr bool
+	}{
+		{name: "classic", g: testField.Grid(), want: solved.Line()},
+		{name: "multiple solutions", g: testField2.Grid()},
+		{name: "variant", g: func() *Grid {
+			g, _ := ParseGrid("...........................4...........49....3.....5............................8")
+			g.Constraints = []Constraint{edges(solved, Greater)}
+			return g
+		}(), want: solved.Line()},
+		{name: "invalid", g: func() *Grid {
+			g := testField.Grid()
+			g.Cells[1] = g.Cells[0]
+			return g
+		}(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SolveGridModel(context.Background(), *tt.g)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SolveGridModel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := got.Check(); err != nil || got.EmptyCells() != 0 {
+				t.Errorf("SolveGridModel() = %s is not solved: %v", got.Line(), err)
+			}
+			if tt.want != "" && got.Line() != tt.want {
+				t.Errorf("SolveGridModel() = %s, want %s", got.Line(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package sudoku
+
+// AntiKnight forbids the same number in two cells a knight's move apart
+type AntiKnight struct{}
+
+// AntiKing forbids the same number in two cells a king's move apart
+type AntiKing struct{}
+
+// NonConsecutive forbids consecutive numbers in orthogonally neighboring cells
+type NonConsecutive struct{}
+
+var (
+	knightMoves     = [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	kingMoves       = [][2]int{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+	orthogonalMoves = [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+)
+
+// Check validates that no number repeats a knight's move apart
+func (AntiKnight) Check(g *Grid) error {
+	return checkMoves(g, knightMoves, KnightMove, same, "both cells hold %[1]d")
+}
+
+// Propagators returns a relation between every two cells a knight's move apart
+func (AntiKnight) Propagators(g *Grid) []Propagator {
+	return moveRelations(g, knightMoves, same)
+}
+
+// Check validates that no number repeats a king's move apart
+func (AntiKing) Check(g *Grid) error {
+	return checkMoves(g, kingMoves, KingMove, same, "both cells hold %[1]d")
+}
+
+// Propagators returns a relation between every two cells a king's move apart
+func (AntiKing) Propagators(g *Grid) []Propagator {
+	return moveRelations(g, kingMoves, same)
+}
+
+// Check validates that no orthogonal neighbors hold consecutive numbers
+func (NonConsecutive) Check(g *Grid) error {
+	return checkMoves(g, orthogonalMoves, Consecutive, consecutive, "%d and %d are consecutive")
+}
+
+// Propagators returns a relation between every two orthogonally neighboring cells
+func (NonConsecutive) Propagators(g *Grid) []Propagator {
+	return moveRelations(g, orthogonalMoves, consecutive)
+}
+
+// conflict returns the numbers which cannot be a move apart from the number n
+type conflict func(n, size int) Candidates
+
+// same conflicts with the number itself
+func same(n, size int) Candidates {
+	return Candidates(0).With(n)
+}
+
+// consecutive conflicts with the numbers one below and one above
+func consecutive(n, size int) Candidates {
+	var c Candidates
+	if n > 1 {
+		c = c.With(n - 1)
+	}
+	if n < size {
+		c = c.With(n + 1)
+	}
+	return c
+}
+
+// neighbors calls f for the cell a move apart from the cell i for every move inside the grid
+func neighbors(g *Grid, i int, moves [][2]int, f func(j int)) {
+	x, y := i%g.Size, i/g.Size
+	for _, m := range moves {
+		nx, ny := x+m[0], y+m[1]
+		if nx >= 0 && nx < g.Size && ny >= 0 && ny < g.Size {
+			f(ny*g.Size + nx)
+		}
+	}
+}
+
+// checkMoves returns an error for the first two set cells a move apart with conflicting numbers
+// The reason is formatted with the numbers of both cells.
+func checkMoves(g *Grid, moves [][2]int, eType ErrorType, c conflict, reason string) error {
+	for i, num := range g.Cells {
+		if num == EmptyCell {
+			continue
+		}
+		var err error
+		neighbors(g, i, moves, func(j int) {
+			other := g.Cells[j]
+			if err == nil && j > i && other != EmptyCell && c(num, g.Size).Has(other) {
+				err = newErrorConstraint(g, eType, []int{i, j}, reason, num, other)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveRelations returns a relation between every two cells a move apart
+// which holds if their numbers do not conflict
+func moveRelations(g *Grid, moves [][2]int, c conflict) []Propagator {
+	var props []Propagator
+	for i := range g.Cells {
+		neighbors(g, i, moves, func(j int) {
+			if j > i {
+				props = append(props, &Relation{A: i, B: j, Holds: func(a, b int) bool {
+					return !c(a, g.Size).Has(b)
+				}})
+			}
+		})
+	}
+	return props
+}
@@ -0,0 +1,136 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNegativeConstraints_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		c         Constraint
+		cells     map[int]int
+		wantType  ErrorType
+		wantCells [][2]int
+	}{
+		{name: "knight", c: AntiKnight{}, cells: map[int]int{0: 5, 11: 6, 19: 4}},
+		{name: "knight repeated", c: AntiKnight{}, cells: map[int]int{0: 5, 19: 5}, wantType: KnightMove, wantCells: [][2]int{{0, 0}, {1, 2}}},
+		{name: "king", c: AntiKing{}, cells: map[int]int{10: 5, 0: 4, 30: 5}},
+		{name: "king repeated", c: AntiKing{}, cells: map[int]int{10: 5, 18: 5}, wantType: KingMove, wantCells: [][2]int{{1, 1}, {0, 2}}},
+		{name: "consecutive", c: NonConsecutive{}, cells: map[int]int{10: 5, 1: 7, 9: 3, 19: 5}},
+		{name: "consecutive neighbors", c: NonConsecutive{}, cells: map[int]int{10: 5, 11: 4}, wantType: Consecutive, wantCells: [][2]int{{1, 1}, {2, 1}}},
+		{name: "consecutive diagonal", c: NonConsecutive{}, cells: map[int]int{10: 5, 20: 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			for i, num := range tt.cells {
+				g.Cells[i] = num
+			}
+			err := tt.c.Check(g)
+			if (err != nil) != (tt.wantType != "") {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantType)
+				return
+			}
+			var errConstraint ErrorConstraint
+			if tt.wantType != "" && (!errors.As(err, &errConstraint) || errConstraint.Type() != tt.wantType) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantType)
+				return
+			}
+			if tt.wantType != "" && !reflect.DeepEqual(errConstraint.Cells(), tt.wantCells) {
+				t.Errorf("Check() error has cells %v, want %v", errConstraint.Cells(), tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestNegativeConstraints_Propagators(t *testing.T) {
+	all := AllCandidates(9)
+	tests := []struct {
+		name string
+		c    Constraint
+		want map[int]Candidates
+	}{
+		{
+			name: "knight",
+			c:    AntiKnight{},
+			// the 5 at (1,1) is a knight's move away from (3,2) and (0,3), but not from (2,2)
+			want: map[int]Candidates{21: all.Without(5), 27: all.Without(5), 20: all},
+		},
+		{
+			name: "king",
+			c:    AntiKing{},
+			want: map[int]Candidates{0: all.Without(5), 20: all.Without(5), 21: all},
+		},
+		{
+			name: "consecutive",
+			c:    NonConsecutive{},
+			want: map[int]Candidates{1: all.Without(4).Without(6), 19: all.Without(4).Without(6), 20: all},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			g.Set(1, 1, 5)
+			cands := make([]Candidates, len(g.Cells))
+			for i := range cands {
+				if g.Cells[i] == EmptyCell {
+					cands[i] = all
+				}
+			}
+			if !propagateConstraint(g, tt.c, cands) {
+				t.Errorf("Propagators() failed")
+			}
+			for i, want := range tt.want {
+				if cands[i] != want {
+					t.Errorf("Propagators() cell %d = %v, want %v", i, cands[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveGrid_Negative(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Constraint
+		line string
+		want string
+	}{
+		{
+			name: "anti-knight",
+			c:    AntiKnight{},
+			line: ".8...76.2..6.9..5...5.32....3......71.....5..7...........2.1................6....",
+			want: "381457692276198453945632178634825917128974536759316284563281749812749365497563821",
+		},
+		{
+			name: "anti-king",
+			c:    AntiKing{},
+			line: "....5.6..2....1....9...3.......7..3.....8..2...8....5...37.9................6..48",
+			want: "381457692275691483694823715126975834537184926948236157853749261462518379719362548",
+		},
+		{
+			name: "non-consecutive",
+			c:    NonConsecutive{},
+			line: "3..................5...2.4.1..........6....1..9..............8..........7.......2",
+			want: "381497526624815379957362841173684295846259713592731468269573184415928637738146952",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := ParseGrid(tt.line)
+			if _, err := SolveGrid(*g, nil); err == nil {
+				t.Errorf("SolveGrid() solved the puzzle without %s", tt.name)
+			}
+			g.Constraints = []Constraint{tt.c}
+			got, err := SolveGrid(*g, nil)
+			if err != nil {
+				t.Fatalf("SolveGrid() error = %v", err)
+			}
+			if got.Line() != tt.want {
+				t.Errorf("SolveGrid() = %s, want %s", got.Line(), tt.want)
+			}
+		})
+	}
+}
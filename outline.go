@@ -0,0 +1,143 @@
+package sudoku
+
+import (
+	"strings"
+)
+
+// styles of the borders between cells
+const (
+	noBorder = iota
+	thinBorder
+	thickBorder
+)
+
+// junctions are the box drawing characters where borders meet
+// The index is up*27 + down*9 + left*3 + right with the style of the border in each direction.
+var junctions = []rune(" ╶╺╴─╼╸╾━╷┌┍┐┬┮┑┭┯╻┎┏┒┰┲┓┱┳╵└┕┘┴┶┙┵┷│├┝┤┼┾┥┽┿╽┟┢┧╁╆┪╅╈╹┖┗┚┸┺┛┹┻╿┞┡┦╀╄┩╃╇┃┠┣┨╂╊┫╉╋")
+
+// marks are printed by drawOutline besides the numbers
+type marks struct {
+	// labels are printed on the top border of a cell
+	labels []string
+	// bracketed cells are printed in brackets
+	bracketed []bool
+	// edges are printed on the border between two adjacent cells, the key holds both cells in reading order
+	edges map[[2]int]rune
+}
+
+// outline prints the grid with a border around every cell, see drawOutline
+func (g Grid) outline(box, group func(i int) int, m marks, format CellFormatter) string {
+	return drawOutline(g.Size, g.Size, box, group, m, func(i int) string {
+		num := digit(g.Cells[i])
+		if format != nil {
+			num = format(i%g.Size, i/g.Size, num)
+		}
+		return num
+	})
+}
+
+// drawOutline prints a layout of width x height cells with a border around every cell
+// Borders between cells of different boxes are thick, between different groups thin,
+// and there is no border within a group. Cells of group -1 belong to no group,
+// cells of box -1 are no part of the puzzle and left blank.
+// The label of a cell is printed on its top border, bracketed cells are printed in brackets
+// and the symbol of an edge in the middle of the border between its cells.
+// e.g. a killer sudoku:
+// ┏3━━━━━━┳7━━┯5━━┓
+// ┃ 1   2 ┃ 3 │ 4 ┃
+// ┃   ┌───╂───┘   ┃
+// ┃ 3 │ 4 ┃ 1   2 ┃
+// ┣━━━┿━━━╋━━━━━━━┫
+// ...
+func drawOutline(width, height int, box, group func(i int) int, m marks, cell func(i int) string) string {
+	// box of the cell (x,y), -1 outside the layout
+	boxAt := func(x, y int) int {
+		if x < 0 || x >= width || y < 0 || y >= height {
+			return -1
+		}
+		return box(y*width + x)
+	}
+	// border between two cells
+	border := func(x1, y1, x2, y2 int) int {
+		a, b := boxAt(x1, y1), boxAt(x2, y2)
+		switch {
+		case a == -1 && b == -1:
+			return noBorder
+		case a != b:
+			return thickBorder
+		}
+		ga, gb := group(y1*width+x1), group(y2*width+x2)
+		if ga == -1 || ga != gb {
+			return thinBorder
+		}
+		return noBorder
+	}
+	// horizontal border above the cell (x,y) and vertical border left of it
+	horizontal := func(x, y int) int {
+		if x < 0 || x >= width {
+			return noBorder
+		}
+		return border(x, y-1, x, y)
+	}
+	vertical := func(x, y int) int {
+		if y < 0 || y >= height {
+			return noBorder
+		}
+		return border(x-1, y, x, y)
+	}
+
+	b := strings.Builder{}
+	b.WriteString("\n")
+	for y := 0; y <= height; y++ {
+		for x := 0; x <= width; x++ {
+			b.WriteRune(junctions[vertical(x, y-1)*27+vertical(x, y)*9+horizontal(x-1, y)*3+horizontal(x, y)])
+			if x == width {
+				break
+			}
+			line := string([]rune(" ─━")[horizontal(x, y)])
+			label := ""
+			if y < height && m.labels != nil {
+				label = m.labels[y*width+x]
+			}
+			if symbol, ok := m.edges[[2]int{(y-1)*width + x, y*width + x}]; ok && label == "" {
+				b.WriteString(line + string(symbol) + line)
+			} else {
+				b.WriteString(label + strings.Repeat(line, 3-len(label)))
+			}
+		}
+		if y == height {
+			break
+		}
+		b.WriteString("\n")
+		for x := 0; x <= width; x++ {
+			i := y*width + x
+			if symbol, ok := m.edges[[2]int{i - 1, i}]; ok && x > 0 && x < width {
+				b.WriteRune(symbol)
+			} else {
+				b.WriteRune([]rune(" │┃")[vertical(x, y)])
+			}
+			if x == width {
+				break
+			}
+			switch {
+			case box(i) == -1:
+				b.WriteString("   ")
+			case m.bracketed != nil && m.bracketed[i]:
+				b.WriteString("[" + cell(i) + "]")
+			default:
+				b.WriteString(" " + cell(i) + " ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// box returns the index of the box or region of the cell i
+func (g Grid) box(i int) int {
+	if g.Regions != nil {
+		return g.Regions[i]
+	}
+	x, y := i%g.Size, i/g.Size
+	return (y/g.BoxHeight)*(g.Size/g.BoxWidth) + x/g.BoxWidth
+}
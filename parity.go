@@ -0,0 +1,34 @@
+package sudoku
+
+// Parity is the rule of shaded cells which must hold an even or odd number
+type Parity struct {
+	// Even and Odd are the indices of the cells in Grid.Cells with the respective parity
+	Even, Odd []int
+}
+
+// Check validates the parity of all set shaded cells
+func (p *Parity) Check(g *Grid) error {
+	for _, i := range p.Even {
+		if num := g.Cells[i]; num != EmptyCell && num%2 != 0 {
+			return newErrorConstraint(g, CellParity, []int{i}, "%d is not even", num)
+		}
+	}
+	for _, i := range p.Odd {
+		if num := g.Cells[i]; num != EmptyCell && num%2 == 0 {
+			return newErrorConstraint(g, CellParity, []int{i}, "%d is not odd", num)
+		}
+	}
+	return nil
+}
+
+// Propagators returns a predicate for the parity of every shaded cell
+func (p *Parity) Propagators(g *Grid) []Propagator {
+	var props []Propagator
+	for _, i := range p.Even {
+		props = append(props, &Predicate{Vars: []int{i}, Holds: func(nums []int) bool { return nums[0]%2 == 0 }})
+	}
+	for _, i := range p.Odd {
+		props = append(props, &Predicate{Vars: []int{i}, Holds: func(nums []int) bool { return nums[0]%2 != 0 }})
+	}
+	return props
+}
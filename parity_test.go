@@ -0,0 +1,106 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParity_Check(t *testing.T) {
+	parity := &Parity{Even: []int{0, 10}, Odd: []int{1}}
+	tests := []struct {
+		name      string
+		cells     map[int]int
+		wantCells [][2]int
+	}{
+		{name: "empty"},
+		{name: "matching", cells: map[int]int{0: 2, 1: 3, 10: 8}},
+		{name: "unshaded", cells: map[int]int{2: 4, 3: 5}},
+		{name: "odd in even cell", cells: map[int]int{0: 2, 10: 5}, wantCells: [][2]int{{1, 1}}},
+		{name: "even in odd cell", cells: map[int]int{1: 4}, wantCells: [][2]int{{1, 0}}},
+		// even cells are checked first and only the first violation is reported
+		{name: "both violated", cells: map[int]int{0: 3, 1: 4}, wantCells: [][2]int{{0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkConstraintErr(t, "Parity.Check()", parity.Check(testGrid(tt.cells)), CellParity, tt.wantCells)
+		})
+	}
+}
+
+func TestParity_Propagators(t *testing.T) {
+	odd := Candidates(0).With(1).With(3).With(5)
+	even := Candidates(0).With(2).With(4).With(6)
+	tests := []struct {
+		name   string
+		g      *Grid
+		parity *Parity
+		// cands are the candidates of the first cells before propagation, all candidates if nil
+		cands []Candidates
+		want  []Candidates
+		ok    bool
+	}{
+		{
+			name:   "9x9",
+			g:      testGrid(map[int]int{2: 4}),
+			parity: &Parity{Even: []int{0, 2}, Odd: []int{1}},
+			want:   []Candidates{even.With(8), odd.With(7).With(9), 0, AllCandidates(9)},
+			ok:     true,
+		},
+		{
+			name:   "6x6",
+			g:      NewGridBoxes(3, 2),
+			parity: &Parity{Even: []int{0}, Odd: []int{1}},
+			want:   []Candidates{even, odd, AllCandidates(6)},
+			ok:     true,
+		},
+		{
+			name:   "set cell of wrong parity",
+			g:      testGrid(map[int]int{0: 3}),
+			parity: &Parity{Even: []int{0}},
+			ok:     false,
+		},
+		{
+			name:   "no even candidate left",
+			g:      testGrid(nil),
+			parity: &Parity{Even: []int{0}},
+			cands:  []Candidates{Candidates(0).With(1).With(3)},
+			ok:     false,
+		},
+		{
+			name:   "shaded as even and odd",
+			g:      testGrid(nil),
+			parity: &Parity{Even: []int{5}, Odd: []int{5}},
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cands := testCands(tt.g)
+			copy(cands, tt.cands)
+			if ok := propagateConstraint(tt.g, tt.parity, cands); ok != tt.ok {
+				t.Fatalf("Parity.Propagators() ok = %v, want %v", ok, tt.ok)
+			}
+			if got := cands[:len(tt.want)]; tt.ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parity.Propagators() pruned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveGrid_Parity(t *testing.T) {
+	solved := testFieldSolved.Grid()
+	g, _ := ParseGrid(".......1.........2...8....34......9....4...3.3.....5......5..41.........576......")
+	// every other cell is shaded like a checkerboard
+	parity := &Parity{}
+	for i, num := range solved.Cells {
+		switch {
+		case (i/9+i%9)%2 != 0:
+		case num%2 == 0:
+			parity.Even = append(parity.Even, i)
+		default:
+			parity.Odd = append(parity.Odd, i)
+		}
+	}
+	g.Constraints = []Constraint{parity}
+	checkSolveGrid(t, g, solved)
+}
@@ -0,0 +1,137 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewSolverField calculates the possible numbers for every cell of a field
+// Cells which are already set have no possible numbers
+func NewSolverField(f *Field) *SolverField {
+	var c SolverField
+	for i, cands := range f.Grid().Candidates() {
+		var pos Possibilities
+		for _, n := range cands.Numbers() {
+			pos.Add(n)
+		}
+		c[i/9][i%9] = &pos
+	}
+	return &c
+}
+
+// PencilMarks turns the field and the possible numbers of its cells into a pencil mark grid
+// Set cells are printed with their number, empty cells list all possible numbers.
+// Empty cells without any possible number are printed as 0.
+// e.g.:
+// .----------------------.--------------------.----------------------.
+// | 7      256     12356 | 39    2369    4    | 8      12356   2369  |
+// | 1236   268     12368 | 3789  236789  5    | 4      1236    2369  |
+// ...
+// '----------------------'--------------------'----------------------'
+func (c SolverField) PencilMarks(f Field) string {
+	var cells [9][9]string
+	var widths [9]int
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			switch {
+			case f[i][j] != EmptyCell:
+				cells[i][j] = fmt.Sprint(f[i][j])
+			case c[i][j] == nil || c[i][j].Empty():
+				cells[i][j] = "0"
+			default:
+				cells[i][j] = c[i][j].digits()
+			}
+			if len(cells[i][j]) > widths[j] {
+				widths[j] = len(cells[i][j])
+			}
+		}
+	}
+
+	// border draws a horizontal line with the given characters at the box corners
+	border := func(b *strings.Builder, left, middle, right string) {
+		for k := 0; k < 3; k++ {
+			if k == 0 {
+				b.WriteString(left)
+			} else {
+				b.WriteString(middle)
+			}
+			width := 2 * 3
+			for j := k * 3; j < k*3+3; j++ {
+				width += widths[j]
+			}
+			b.WriteString(strings.Repeat("-", width))
+		}
+		b.WriteString(right + "\n")
+	}
+
+	b := strings.Builder{}
+	border(&b, ".", ".", ".")
+	for i := 0; i < 9; i++ {
+		if i > 0 && i%3 == 0 {
+			border(&b, ":", "+", ":")
+		}
+		for j := 0; j < 9; j++ {
+			if j%3 == 0 {
+				b.WriteString("| ")
+			}
+			b.WriteString(cells[i][j] + strings.Repeat(" ", widths[j]-len(cells[i][j])+1))
+			if j%3 != 2 {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("|\n")
+	}
+	border(&b, "'", "'", "'")
+	return b.String()
+}
+
+// ParsePencilMarks reads a pencil mark grid as written by SolverField.PencilMarks
+// Cells with a single number are read as set cells like in the pencil mark grids of sudoku forums,
+// 0 means no number is possible.
+// The borders are optional, only the order of the 81 cells matters.
+func ParsePencilMarks(s string) (*Field, *SolverField, error) {
+	// remove borders
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(".-:|'+*", r) {
+			return ' '
+		}
+		return r
+	}, s)
+	cells := strings.Fields(s)
+	if len(cells) != 9*9 {
+		return nil, nil, fmt.Errorf("Pencil marks have %d cells instead of %d", len(cells), 9*9)
+	}
+
+	var field Field
+	var check SolverField
+	for n, cell := range cells {
+		i, j := n/9, n%9
+		pos := new(Possibilities)
+		for _, r := range cell {
+			if r < '0' || r > '9' {
+				return nil, nil, fmt.Errorf("Cell (%d,%d) contains invalid character '%c'", j, i, r)
+			}
+			if r != '0' {
+				pos.Add(int(r - '0'))
+			}
+		}
+		if ok, num := pos.OnlyOne(); ok {
+			field[i][j] = num
+			pos = new(Possibilities)
+		}
+		check[i][j] = pos
+	}
+	return &field, &check, nil
+}
+
+// digits lists all possible numbers without separator
+// e.g. 458
+func (p *Possibilities) digits() string {
+	b := make([]byte, 0, len(p))
+	for n, v := range p {
+		if v {
+			b = append(b, byte('1'+n))
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,157 @@
+package sudoku
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSolverField(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *Field
+		x, y int
+		want *Possibilities
+	}{
+		{
+			name: "empty field",
+			f:    &Field{},
+			want: NewPossibilities(),
+		},
+		{
+			name: "set cell",
+			f:    testField,
+			x:    0,
+			y:    0,
+			want: &Possibilities{},
+		},
+		{
+			name: "empty cell",
+			f:    testField,
+			x:    1,
+			y:    0,
+			want: &Possibilities{false, true, false, false, true, true, false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSolverField(tt.f)[tt.y][tt.x]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSolverField()[%d][%d] = %v, want %v", tt.y, tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolverField_PencilMarks(t *testing.T) {
+	// eliminate a number by hand and clear all possibilities of another cell
+	check := NewSolverField(testField)
+	check[0][1].Remove(6)
+	check[8][8] = new(Possibilities)
+
+	got := check.PencilMarks(*testField)
+	lines := strings.Split(got, "\n")
+	want := map[int]string{
+		0:  ".----------------------.--------------------.----------------------.",
+		1:  "| 7      25      12356 | 39    2369    4    | 8      12356   2369  |",
+		4:  ":----------------------+--------------------+----------------------:",
+		11: "| 2569   256789  2568  | 1     4       3    | 269    268     0     |",
+		12: "'----------------------'--------------------'----------------------'",
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("SolverField.PencilMarks() line %d = '%v', want '%v'", i, lines[i], line)
+		}
+	}
+
+	// the grid must be readable again
+	field, parsed, err := ParsePencilMarks(got)
+	if err != nil {
+		t.Errorf("ParsePencilMarks() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(field, testField) {
+		t.Errorf("ParsePencilMarks() field = %v, want %v", field, testField)
+	}
+	if !reflect.DeepEqual(parsed, check) {
+		t.Errorf("ParsePencilMarks() possibilities = %v, want %v", parsed, check)
+	}
+}
+
+func TestParsePencilMarks(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    *Field
+		wantErr bool
+	}{
+		{
+			name:    "without borders",
+			s:       strings.Repeat("123456789 ", 80) + "5",
+			want:    &Field{8: {8: 5}},
+			wantErr: false,
+		},
+		{
+			name:    "too little cells",
+			s:       strings.Repeat("12 ", 80),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			s:       strings.Repeat("12 ", 80) + "1a",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := ParsePencilMarks(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePencilMarks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePencilMarks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// forumPencilMarks is a pencil mark grid of testField as posted on sudoku forums,
+// the 6 of the second cell is eliminated by hand
+const forumPencilMarks = `
+*-----------------------------------------------------------------------------*
+| 7       25      12356   | 39      2369    4       | 8       12356   2369    |
+| 1236    268     12368   | 3789    236789  5       | 4       1236    2369    |
+| 12356   24568   9       | 38      2368    1268    | 7       12356   236     |
+|-------------------------+-------------------------+-------------------------|
+| 4       256     12356   | 3578    2378    278     | 12356   9       23678   |
+| 8       2569    7       | 3459    239     29      | 12356   123456  2346    |
+| 2359    259     235     | 6       1       2789    | 235     23458   23478   |
+|-------------------------+-------------------------+-------------------------|
+| 269     3       2468    | 789     5       6789    | 269     2468    1       |
+| 69      1       468     | 2       689     689     | 369     7       5       |
+| 2569    256789  2568    | 1       4       3       | 269     268     2689    |
+*-----------------------------------------------------------------------------*
+`
+
+func TestParsePencilMarks_Forum(t *testing.T) {
+	field, check, err := ParsePencilMarks(forumPencilMarks)
+	if err != nil {
+		t.Fatalf("ParsePencilMarks() error = %v", err)
+	}
+	if !reflect.DeepEqual(field, testField) {
+		t.Errorf("ParsePencilMarks() field = %v, want the givens %v", field, testField)
+	}
+	want := NewSolverField(testField)
+	want[0][1].Remove(6)
+	if !reflect.DeepEqual(check, want) {
+		t.Errorf("ParsePencilMarks() possibilities = %v, want %v", check, want)
+	}
+
+	// writing and reading it again keeps givens and eliminations
+	field2, check2, err := ParsePencilMarks(check.PencilMarks(*field))
+	if err != nil || !reflect.DeepEqual(field2, field) || !reflect.DeepEqual(check2, check) {
+		t.Errorf("ParsePencilMarks(SolverField.PencilMarks()) = %v, %v, %v, want %v, %v", field2, check2, err, field, check)
+	}
+}
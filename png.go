@@ -0,0 +1,179 @@
+package sudoku
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// Colors are the colors used by RenderPNG
+type Colors struct {
+	Background color.Color
+	Grid       color.Color
+	Given      color.Color
+	Solved     color.Color
+	Conflict   color.Color
+	Candidate  color.Color
+	Highlight  color.Color
+}
+
+// DefaultColors are used by RenderPNG if no colors are set
+// solved numbers use the same green as PrettyPrint
+var DefaultColors = Colors{
+	Background: color.White,
+	Grid:       color.Black,
+	Given:      color.Black,
+	Solved:     color.RGBA{0x1a, 0x9f, 0x1a, 0xff},
+	Conflict:   color.RGBA{0xd0, 0x20, 0x20, 0xff},
+	Candidate:  color.Gray{0x66},
+	Highlight:  color.RGBA{0xff, 0xf0, 0xa0, 0xff},
+}
+
+// glyphs is a 5x7 bitmap font for the numbers 1 to 9 and the letters A to Z of larger grids
+var glyphs = [MaxSize][7]string{
+	{"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	{".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	{"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	{"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	{"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	{"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	{"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	{".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	{".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	{".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	{"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	{".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	{"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	{"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	{"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	{".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	{"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	{".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	{"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	{"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	{"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	{"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	{"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	{".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	{"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	{".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	{"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	{".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	{"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	{"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	{"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	{"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	{"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	{"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	{"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+}
+
+// RenderPNG writes the field as PNG image
+// See Grid.RenderPNG
+func (f Field) RenderPNG(w io.Writer, opts RenderOptions) error {
+	return f.Grid().RenderPNG(w, opts)
+}
+
+// Image draws the field as image
+// See Grid.RenderPNG
+func (f Field) Image(opts RenderOptions) image.Image {
+	return f.Grid().Image(opts)
+}
+
+// RenderPNG writes the grid as PNG image
+// Set cells which are not part of opts.Initial are drawn in the solved color,
+// numbers which violate the sudoku rules in the conflict color.
+// If opts.Step is set, the cells it was found in are highlighted.
+func (g *Grid) RenderPNG(w io.Writer, opts RenderOptions) error {
+	return png.Encode(w, g.Image(opts))
+}
+
+// Image draws the grid as image
+// See Grid.RenderPNG
+func (g *Grid) Image(opts RenderOptions) image.Image {
+	colors := DefaultColors
+	if opts.Colors != nil {
+		colors = *opts.Colors
+	}
+	n := g.Size
+	cell := opts.cellSize()
+	margin := 2
+	size := n*cell + 2*margin
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	fill := func(r image.Rectangle, c color.Color) {
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	cellRect := func(x, y int) image.Rectangle {
+		return image.Rect(margin+x*cell, margin+y*cell, margin+(x+1)*cell, margin+(y+1)*cell)
+	}
+	fill(img.Bounds(), colors.Background)
+
+	if opts.Step != nil {
+		for _, c := range g.StepCells(*opts.Step) {
+			fill(cellRect(c[0], c[1]), colors.Highlight)
+		}
+	}
+
+	// grid lines, the thick lines are centered on the thin ones
+	for i := 1; i < n; i++ {
+		p := margin + i*cell
+		fill(image.Rect(p, margin-1, p+1, size-margin+1), colors.Grid)
+		fill(image.Rect(margin-1, p, size-margin+1, p+1), colors.Grid)
+	}
+	for _, l := range g.thickLines() {
+		fill(image.Rect(margin+l[0]*cell-1, margin+l[1]*cell-1, margin+l[2]*cell+2, margin+l[3]*cell+2), colors.Grid)
+	}
+
+	conflicts := g.Conflicts()
+	cols, rows := g.candidateLayout()
+	sub := cell / cols
+	if rows > cols {
+		sub = cell / rows
+	}
+	for i, num := range g.Cells {
+		r := cellRect(i%n, i/n)
+		if num != EmptyCell {
+			c := colors.Given
+			switch {
+			case conflicts[i]:
+				c = colors.Conflict
+			case opts.solved(g, i):
+				c = colors.Solved
+			}
+			drawGlyph(img, r, num, cell*3/5/7, c)
+			continue
+		}
+		if opts.Candidates == nil {
+			continue
+		}
+		for c := 1; c <= n; c++ {
+			if opts.Candidates[i].Has(c) {
+				x, y := r.Min.X+((c-1)%cols)*sub, r.Min.Y+((c-1)/cols)*sub
+				drawGlyph(img, image.Rect(x, y, x+sub, y+sub), c, sub*3/4/7, colors.Candidate)
+			}
+		}
+	}
+	return img
+}
+
+// drawGlyph draws the number centered in r, every pixel of the font is scaled by scale
+func drawGlyph(img *image.RGBA, r image.Rectangle, num, scale int, c color.Color) {
+	if scale < 1 {
+		scale = 1
+	}
+	glyph := glyphs[num-1]
+	x0 := r.Min.X + (r.Dx()-5*scale)/2
+	y0 := r.Min.Y + (r.Dy()-7*scale)/2
+	src := image.NewUniform(c)
+	for y, row := range glyph {
+		for x, p := range row {
+			if p == '#' {
+				px := image.Rect(x0+x*scale, y0+y*scale, x0+(x+1)*scale, y0+(y+1)*scale)
+				draw.Draw(img, px, src, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
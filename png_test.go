@@ -0,0 +1,181 @@
+package sudoku
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// colorsInCell collects all colors in the inner part of a cell
+func colorsInCell(img image.Image, x, y, cell int) map[color.RGBA]bool {
+	found := map[color.RGBA]bool{}
+	for py := 2 + y*cell + 2; py < 2+(y+1)*cell-2; py++ {
+		for px := 2 + x*cell + 2; px < 2+(x+1)*cell-2; px++ {
+			r, g, b, a := img.At(px, py).RGBA()
+			found[color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}] = true
+		}
+	}
+	return found
+}
+
+func rgba(c color.Color) color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
+
+func TestField_RenderPNG(t *testing.T) {
+	conflict := *testFieldSolved
+	conflict[0][1] = 7
+	custom := DefaultColors
+	custom.Given = color.RGBA{0, 0, 0xff, 0xff}
+
+	tests := []struct {
+		name  string
+		f     Field
+		opts  RenderOptions
+		size  int
+		x, y  int
+		color color.Color
+	}{
+		{
+			name:  "given",
+			f:     *testField,
+			opts:  RenderOptions{},
+			size:  9*40 + 4,
+			x:     0,
+			y:     0,
+			color: DefaultColors.Given,
+		},
+		{
+			name:  "solved",
+			f:     *testFieldSolved,
+			opts:  RenderOptions{CellSize: 30, Initial: testField.Grid()},
+			size:  9*30 + 4,
+			x:     1,
+			y:     0,
+			color: DefaultColors.Solved,
+		},
+		{
+			name:  "conflict",
+			f:     conflict,
+			opts:  RenderOptions{Initial: testField.Grid()},
+			size:  9*40 + 4,
+			x:     0,
+			y:     0,
+			color: DefaultColors.Conflict,
+		},
+		{
+			name:  "highlighted step",
+			f:     *testField,
+			opts:  RenderOptions{Step: &Step{X: 1, Y: 2, Num: 4, Strategy: HiddenSingleSquare}},
+			size:  9*40 + 4,
+			x:     2,
+			y:     0,
+			color: DefaultColors.Highlight,
+		},
+		{
+			name:  "candidates",
+			f:     *testField,
+			opts:  RenderOptions{Candidates: testField.Grid().Candidates()},
+			size:  9*40 + 4,
+			x:     1,
+			y:     0,
+			color: DefaultColors.Candidate,
+		},
+		{
+			name:  "custom colors",
+			f:     *testField,
+			opts:  RenderOptions{Colors: &custom},
+			size:  9*40 + 4,
+			x:     0,
+			y:     0,
+			color: custom.Given,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.f.RenderPNG(&b, tt.opts); err != nil {
+				t.Errorf("Field.RenderPNG() error = %v", err)
+				return
+			}
+			img, err := png.Decode(&b)
+			if err != nil {
+				t.Errorf("Field.RenderPNG() returned invalid PNG: %v", err)
+				return
+			}
+			if got := img.Bounds().Dx(); got != tt.size {
+				t.Errorf("Field.RenderPNG() width = %d, want %d", got, tt.size)
+			}
+			if !colorsInCell(img, tt.x, tt.y, tt.opts.cellSize())[rgba(tt.color)] {
+				t.Errorf("Field.RenderPNG() cell (%d,%d) does not contain color %v", tt.x, tt.y, tt.color)
+			}
+		})
+	}
+}
+
+func TestGrid_RenderPNG(t *testing.T) {
+	big := patternGrid(4, 4)
+	big.Cells[1] = big.Cells[0]
+	tests := []struct {
+		name  string
+		g     *Grid
+		opts  RenderOptions
+		x, y  int
+		color color.Color
+	}{
+		{
+			name:  "letters",
+			g:     patternGrid(4, 4),
+			x:     15,
+			y:     0,
+			color: DefaultColors.Given,
+		},
+		{
+			name:  "conflict",
+			g:     big,
+			opts:  RenderOptions{CellSize: 30},
+			x:     1,
+			y:     0,
+			color: DefaultColors.Conflict,
+		},
+		{
+			name:  "highlighted box",
+			g:     NewGridBoxes(3, 2),
+			opts:  RenderOptions{Step: &Step{X: 4, Y: 3, Num: 1, Strategy: HiddenSingleSquare}},
+			x:     3,
+			y:     2,
+			color: DefaultColors.Highlight,
+		},
+		{
+			name:  "jigsaw candidates",
+			g:     jigsaw(strings.Repeat(".", 16), "AABBACBBACDDCCDD"),
+			opts:  RenderOptions{Candidates: jigsaw(strings.Repeat(".", 16), "AABBACBBACDDCCDD").Candidates()},
+			x:     3,
+			y:     3,
+			color: DefaultColors.Candidate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.g.RenderPNG(&b, tt.opts); err != nil {
+				t.Errorf("Grid.RenderPNG() error = %v", err)
+				return
+			}
+			img, err := png.Decode(&b)
+			if err != nil {
+				t.Errorf("Grid.RenderPNG() returned invalid PNG: %v", err)
+				return
+			}
+			if got, want := img.Bounds().Dx(), tt.g.Size*tt.opts.cellSize()+4; got != want {
+				t.Errorf("Grid.RenderPNG() width = %d, want %d", got, want)
+			}
+			if !colorsInCell(img, tt.x, tt.y, tt.opts.cellSize())[rgba(tt.color)] {
+				t.Errorf("Grid.RenderPNG() cell (%d,%d) does not contain color %v", tt.x, tt.y, tt.color)
+			}
+		})
+	}
+}
@@ -72,17 +72,3 @@ func (p Possibilities) String() string {
 	out += "]"
 	return out
 }
-
-// merges multiple Possibilities into one,
-// where a number is only possible if it is possible in all given Possibilities
-func mergePossibilities(poses ...*Possibilities) *Possibilities {
-	checker := NewPossibilities()
-	for i := range poses[0] {
-		for _, c := range poses {
-			if !c.IsPossible(i+1) && checker.IsPossible(i+1) {
-				checker.Remove(i + 1)
-			}
-		}
-	}
-	return checker
-}
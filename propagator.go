@@ -0,0 +1,177 @@
+package sudoku
+
+// AllDifferent is the rule that no number is repeated among its variables
+type AllDifferent struct {
+	// Vars are the indices of the variables in Model.Domains
+	Vars []int
+}
+
+// Scope returns the variables of the group
+func (a *AllDifferent) Scope() []int {
+	return a.Vars
+}
+
+// Propagate removes the numbers of set variables from all open ones
+// It fails if a number is set twice or the open variables have less numbers left than there are variables.
+func (a *AllDifferent) Propagate(values []int, domains []Candidates) bool {
+	var fixed, union Candidates
+	for _, v := range a.Vars {
+		if num := values[v]; num != EmptyCell {
+			if fixed.Has(num) {
+				return false
+			}
+			fixed = fixed.With(num)
+		}
+	}
+	open := 0
+	for _, v := range a.Vars {
+		if values[v] == EmptyCell {
+			domains[v] &^= fixed
+			union |= domains[v]
+			open++
+		}
+	}
+	return union.Count() >= open
+}
+
+// Sum is the rule that the numbers of its variables add up to Total
+// Numbers may repeat, combine it with AllDifferent otherwise.
+type Sum struct {
+	Total int
+	// Vars are the indices of the variables in Model.Domains
+	Vars []int
+	// Weights multiply the numbers of Vars in the same order, all weights are 1 if empty
+	// e.g. the circle of an arrow is subtracted with a weight of -1 to a total of 0
+	Weights []int
+}
+
+// Scope returns the variables of the sum
+func (s *Sum) Scope() []int {
+	return s.Vars
+}
+
+// weight returns the factor of the j-th variable
+func (s *Sum) weight(j int) int {
+	if len(s.Weights) == 0 {
+		return 1
+	}
+	return s.Weights[j]
+}
+
+// Propagate restricts every variable to the numbers which keep the total reachable
+func (s *Sum) Propagate(values []int, domains []Candidates) bool {
+	minSum, maxSum := 0, 0
+	lo, hi := make([]int, len(s.Vars)), make([]int, len(s.Vars))
+	for j, v := range s.Vars {
+		min, max := bounds(values, domains, v)
+		if min == 0 {
+			return false
+		}
+		w := s.weight(j)
+		lo[j], hi[j] = w*min, w*max
+		if w < 0 {
+			lo[j], hi[j] = hi[j], lo[j]
+		}
+		minSum += lo[j]
+		maxSum += hi[j]
+	}
+	if minSum > s.Total || maxSum < s.Total {
+		return false
+	}
+	for j, v := range s.Vars {
+		// the other variables add at least minSum-lo[j] and at most maxSum-hi[j]
+		min, max := s.Total-(maxSum-hi[j]), s.Total-(minSum-lo[j])
+		w := s.weight(j)
+		if w < 0 {
+			min, max = max, min
+		}
+		restrict(values, domains, v, between(ceilDiv(min, w), floorDiv(max, w)))
+	}
+	return true
+}
+
+// floorDiv divides a by b rounding down
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// ceilDiv divides a by b rounding up
+func ceilDiv(a, b int) int {
+	return -floorDiv(-a, b)
+}
+
+// Relation is a rule between the numbers of two variables, e.g. a kropki dot
+type Relation struct {
+	// A and B are the indices of the variables in Model.Domains
+	A, B  int
+	Holds func(a, b int) bool
+}
+
+// Scope returns both variables of the relation
+func (r *Relation) Scope() []int {
+	return []int{r.A, r.B}
+}
+
+// Propagate keeps the numbers of both variables which have a matching number in the other one
+func (r *Relation) Propagate(values []int, domains []Candidates) bool {
+	var allowedA, allowedB Candidates
+	for _, a := range value(values, domains, r.A).Numbers() {
+		for _, b := range value(values, domains, r.B).Numbers() {
+			if r.Holds(a, b) {
+				allowedA, allowedB = allowedA.With(a), allowedB.With(b)
+			}
+		}
+	}
+	restrict(values, domains, r.A, allowedA)
+	restrict(values, domains, r.B, allowedB)
+	return allowedA != 0
+}
+
+// Predicate is any rule written in Go for the numbers of its variables
+// Holds gets the numbers in the order of Vars once all but one of them are known,
+// a variable is known if it is set or has a single number left.
+type Predicate struct {
+	// Vars are the indices of the variables in Model.Domains
+	Vars  []int
+	Holds func(nums []int) bool
+}
+
+// Scope returns the variables of the predicate
+func (p *Predicate) Scope() []int {
+	return p.Vars
+}
+
+// Propagate keeps the numbers of the last unknown variable for which the predicate holds
+// It fails if the predicate does not hold for the known variables.
+func (p *Predicate) Propagate(values []int, domains []Candidates) bool {
+	nums := make([]int, len(p.Vars))
+	open := -1
+	for j, v := range p.Vars {
+		num, ok := value(values, domains, v).Single()
+		switch {
+		case ok:
+			nums[j] = num
+		case open != -1:
+			// too many unknown variables to decide anything
+			return true
+		default:
+			open = j
+		}
+	}
+	if open == -1 {
+		return p.Holds(nums)
+	}
+	var allowed Candidates
+	for _, num := range domains[p.Vars[open]].Numbers() {
+		nums[open] = num
+		if p.Holds(nums) {
+			allowed = allowed.With(num)
+		}
+	}
+	domains[p.Vars[open]] = allowed
+	return allowed != 0
+}
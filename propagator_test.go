@@ -0,0 +1,157 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropagator_Propagate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Propagator
+		values  []int
+		domains []Candidates
+		want    []Candidates
+		wantOk  bool
+	}{
+		{
+			name:    "all different removes set numbers",
+			p:       &AllDifferent{Vars: []int{0, 1, 2}},
+			values:  []int{3, 0, 0},
+			domains: []Candidates{0, between(1, 3), between(2, 5)},
+			want:    []Candidates{0, between(1, 2), Candidates(0).With(2).With(4).With(5)},
+			wantOk:  true,
+		},
+		{
+			name:    "all different keeps single numbers open",
+			p:       &AllDifferent{Vars: []int{0, 1, 2}},
+			domains: []Candidates{between(1, 1), between(1, 2), between(1, 3)},
+			want:    []Candidates{between(1, 1), between(1, 2), between(1, 3)},
+			wantOk:  true,
+		},
+		{
+			name:    "all different with repeated number",
+			p:       &AllDifferent{Vars: []int{0, 1}},
+			values:  []int{2, 2},
+			domains: []Candidates{0, 0},
+		},
+		{
+			name:    "all different without enough numbers",
+			p:       &AllDifferent{Vars: []int{0, 1, 2}},
+			domains: []Candidates{between(1, 2), between(1, 2), between(1, 2)},
+		},
+		{
+			name:    "sum",
+			p:       &Sum{Total: 5, Vars: []int{0, 1}},
+			domains: []Candidates{between(1, 9), between(3, 9)},
+			want:    []Candidates{between(1, 2), between(3, 4)},
+			wantOk:  true,
+		},
+		{
+			name:    "sum with set variable",
+			p:       &Sum{Total: 5, Vars: []int{0, 1}},
+			values:  []int{2, 0},
+			domains: []Candidates{0, between(1, 9)},
+			want:    []Candidates{0, between(3, 3)},
+			wantOk:  true,
+		},
+		{
+			name:    "weighted sum",
+			p:       &Sum{Vars: []int{0, 1, 2}, Weights: []int{1, 1, -1}},
+			domains: []Candidates{between(1, 9), between(1, 9), between(1, 4)},
+			want:    []Candidates{between(1, 3), between(1, 3), between(2, 4)},
+			wantOk:  true,
+		},
+		{
+			name:    "sum out of reach",
+			p:       &Sum{Total: 20, Vars: []int{0, 1}},
+			domains: []Candidates{between(1, 9), between(3, 9)},
+		},
+		{
+			name:    "relation",
+			p:       &Relation{A: 0, B: 1, Holds: func(a, b int) bool { return a == 2*b }},
+			domains: []Candidates{between(1, 9), between(4, 9)},
+			want:    []Candidates{Candidates(0).With(8), between(4, 4)},
+			wantOk:  true,
+		},
+		{
+			name:    "impossible relation",
+			p:       &Relation{A: 0, B: 1, Holds: func(a, b int) bool { return a > b }},
+			domains: []Candidates{between(1, 3), between(3, 9)},
+		},
+		{
+			name:    "predicate with open variables",
+			p:       &Predicate{Vars: []int{0, 1, 2}, Holds: func(nums []int) bool { return false }},
+			domains: []Candidates{between(1, 2), between(1, 2), between(1, 9)},
+			want:    []Candidates{between(1, 2), between(1, 2), between(1, 9)},
+			wantOk:  true,
+		},
+		{
+			name: "predicate with one open variable",
+			p: &Predicate{Vars: []int{0, 1, 2}, Holds: func(nums []int) bool {
+				return nums[0]*nums[1] == nums[2]
+			}},
+			domains: []Candidates{between(2, 2), between(3, 3), between(1, 9)},
+			want:    []Candidates{between(2, 2), between(3, 3), between(6, 6)},
+			wantOk:  true,
+		},
+		{
+			name:    "predicate not holding",
+			p:       &Predicate{Vars: []int{0, 1}, Holds: func(nums []int) bool { return nums[0] != nums[1] }},
+			values:  []int{4, 0},
+			domains: []Candidates{0, between(4, 4)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := tt.values
+			if values == nil {
+				values = make([]int, len(tt.domains))
+			}
+			ok := tt.p.Propagate(values, tt.domains)
+			if ok != tt.wantOk {
+				t.Fatalf("Propagator.Propagate() = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !reflect.DeepEqual(tt.domains, tt.want) {
+				t.Errorf("Propagator.Propagate() domains = %v, want %v", tt.domains, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid_Model(t *testing.T) {
+	g, _ := ParseGrid("1...............")
+	g.Extra = Diagonals(4)
+	g.Constraints = []Constraint{&Thermometer{Cells: []int{1, 2}}}
+	g.Marks = make([]Candidates, len(g.Cells))
+	for i := range g.Marks {
+		g.Marks[i] = AllCandidates(4)
+	}
+	g.Marks[3] = between(2, 3)
+	m := g.Model()
+	if want := 4*3 + 2 + 1; len(m.Propagators) != want {
+		t.Errorf("Grid.Model() has %d propagators, want %d", len(m.Propagators), want)
+	}
+	if m.Values[0] != 1 || m.Values[1] != EmptyCell {
+		t.Errorf("Grid.Model() values = %v", m.Values[:2])
+	}
+	if m.Domains[0] != 0 || m.Domains[1] != AllCandidates(4) || m.Domains[3] != between(2, 3) {
+		t.Errorf("Grid.Model() domains = %v", m.Domains[:4])
+	}
+	if !m.Propagate() {
+		t.Fatalf("Model.Propagate() failed")
+	}
+	if m.Domains[1] != between(2, 3) || m.Domains[2] != between(3, 4) {
+		t.Errorf("Model.Propagate() = %v %v, want %v %v", m.Domains[1], m.Domains[2], between(2, 3), between(3, 4))
+	}
+	if !m.Set(1, 3) || m.Domains[2] != between(4, 4) {
+		t.Errorf("Model.Set() domains = %v, want %v", m.Domains[2], between(4, 4))
+	}
+}
+
+// propagateConstraint runs the propagators of the constraint on the candidates of the grid
+// until none of them removes a candidate
+func propagateConstraint(g *Grid, c Constraint, cands []Candidates) bool {
+	m := &Model{Values: g.Cells, Domains: cands, Propagators: c.Propagators(g)}
+	return m.Propagate()
+}
@@ -0,0 +1,92 @@
+package sudoku
+
+// RenderOptions configures the rendering of a grid as image
+type RenderOptions struct {
+	// CellSize is the width and height of a cell in pixels
+	// Defaults to 40 if not set
+	CellSize int
+
+	// Initial is the grid before solving
+	// Cells which differ from it are drawn as solved cells
+	Initial *Grid
+
+	// Candidates of every cell are drawn as small numbers in empty cells if set
+	Candidates []Candidates
+
+	// Step highlights the house a solver step was found in
+	Step *Step
+
+	// Colors overwrites DefaultColors for PNG images
+	// SVG images are styled with CSS classes instead
+	Colors *Colors
+}
+
+// cellSize returns the configured cell size or the default
+func (o *RenderOptions) cellSize() int {
+	if o.CellSize > 0 {
+		return o.CellSize
+	}
+	return 40
+}
+
+// solved returns true if the number of the cell i differs from the initial grid
+func (o *RenderOptions) solved(g *Grid, i int) bool {
+	return o.Initial != nil && g.Cells[i] != o.Initial.Cells[i]
+}
+
+// candidateLayout returns the columns and rows of the small box the candidates of a cell are placed in
+// It has the shape of the boxes of the grid, e.g. a phone keypad for 9x9 grids.
+func (g *Grid) candidateLayout() (cols, rows int) {
+	if g.BoxWidth*g.BoxHeight == g.Size {
+		return g.BoxWidth, g.BoxHeight
+	}
+	cols, rows, _ = BoxSize(g.Size)
+	return cols, rows
+}
+
+// thickLines returns the thick borders of the grid as lines from (x1,y1) to (x2,y2),
+// counted in cells from the upper left corner
+// Borders are thick at the edge of the grid and between different boxes or regions,
+// adjacent thick borders on one line are merged.
+func (g *Grid) thickLines() [][4]int {
+	n := g.Size
+	// thick returns if the border before the j-th cell on the i-th vertical or horizontal line is thick
+	thick := func(vertical bool, i, j int) bool {
+		if i == 0 || i == n {
+			return true
+		}
+		if vertical {
+			return g.box(j*n+i-1) != g.box(j*n+i)
+		}
+		return g.box((i-1)*n+j) != g.box(i*n+j)
+	}
+	var lines [][4]int
+	for _, vertical := range []bool{true, false} {
+		for i := 0; i <= n; i++ {
+			for j := 0; j < n; j++ {
+				if !thick(vertical, i, j) {
+					continue
+				}
+				start := j
+				for j+1 < n && thick(vertical, i, j+1) {
+					j++
+				}
+				if vertical {
+					lines = append(lines, [4]int{i, start, i, j + 1})
+				} else {
+					lines = append(lines, [4]int{start, i, j + 1, i})
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// borders returns which borders of the cell i are thick
+// Borders are thick between different boxes or regions, the edge of the grid is not reported.
+func (g *Grid) borders(i int) (right, bottom bool) {
+	x, y := i%g.Size, i/g.Size
+	right = x < g.Size-1 && g.box(i) != g.box(i+1)
+	bottom = y < g.Size-1 && g.box(i) != g.box(i+g.Size)
+	return right, bottom
+}
@@ -0,0 +1,120 @@
+package sudoku
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status describes how far a grid could be solved
+type Status string
+
+const (
+	// Solved means all cells are set
+	Solved = Status("solved")
+
+	// Stuck means the solver could not set all cells
+	Stuck = Status("stuck")
+
+	// Invalid means the grid violates the sudoku rules
+	Invalid = Status("invalid")
+)
+
+// Result is the outcome of solving a single grid
+type Result struct {
+	// Index is the position of the grid in the input of SolveAll or SolveAllGrids
+	Index int
+	// Puzzle is the unsolved input grid
+	Puzzle *Grid
+	// Solution is the grid returned by SolveGrid
+	// It is only partially filled if Err is not nil
+	Solution *Grid
+	// Steps are all cells set by the solver in order
+	Steps []Step
+	// Rating is the difficulty of the grid
+	Rating Difficulty
+	// SolveTime is the time needed to solve and rate the grid
+	SolveTime time.Duration
+	// Err is the error returned by SolveGrid
+	Err error
+}
+
+// SolveResult solves and rates the field
+// See SolveGridResult
+func SolveResult(ctx context.Context, f Field) Result {
+	return SolveGridResult(ctx, *f.Grid())
+}
+
+// SolveGridResult solves and rates a grid of any size
+// Both happen in a single pass, every step uses the easiest possible strategy like RateGrid.
+// Solving stops once the context is done, Err is the error of the context then.
+func SolveGridResult(ctx context.Context, g Grid) Result {
+	r := Result{Puzzle: g.Clone(), Solution: g.Clone()}
+	if err := g.Check(); err != nil {
+		r.Err = fmt.Errorf("field is invalid: %w", err)
+		return r
+	}
+
+	start := time.Now()
+	r.Rating, r.Err = r.Solution.rate(ctx, func(s Step) {
+		r.Steps = append(r.Steps, s)
+	})
+	r.SolveTime = time.Since(start)
+	return r
+}
+
+// Status returns the status derived from the error of the result
+func (r Result) Status() Status {
+	var errSudoku ErrorSudoku
+	var errConstraint ErrorConstraint
+	switch {
+	case r.Err == nil:
+		return Solved
+	case errors.As(r.Err, &errSudoku), errors.As(r.Err, &errConstraint):
+		return Invalid
+	}
+	return Stuck
+}
+
+// resultJSON is the JSON document of a result
+type resultJSON struct {
+	Index    int         `json:"index"`
+	Puzzle   *Grid       `json:"puzzle"`
+	Solution *Grid       `json:"solution,omitempty"`
+	Status   Status      `json:"status"`
+	Error    string      `json:"error,omitempty"`
+	Steps    []Step      `json:"steps"`
+	Rating   *Difficulty `json:"rating,omitempty"`
+	Timings  struct {
+		Solve time.Duration `json:"solve"`
+	} `json:"timings"`
+}
+
+// MarshalJSON turns the result into a JSON document
+// The timings are given in nanoseconds
+// e.g.:
+// {"index":0,"puzzle":"7....48...","solution":"725364819...","status":"solved",
+// "steps":[{"x":1,"y":2,"number":4,"strategy":"hidden single in row"},...],
+// "rating":"medium","timings":{"solve":93000}}
+func (r Result) MarshalJSON() ([]byte, error) {
+	doc := resultJSON{
+		Index:    r.Index,
+		Puzzle:   r.Puzzle,
+		Solution: r.Solution,
+		Status:   r.Status(),
+		Steps:    r.Steps,
+	}
+	if r.Err != nil {
+		doc.Error = r.Err.Error()
+	}
+	if doc.Steps == nil {
+		doc.Steps = []Step{}
+	}
+	if r.Rating != 0 {
+		doc.Rating = &r.Rating
+	}
+	doc.Timings.Solve = r.SolveTime
+	return json.Marshal(doc)
+}
@@ -0,0 +1,144 @@
+package sudoku
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSolveResult(t *testing.T) {
+	// generate field with error
+	errorfield := *testField
+	errorfield[0][1] = 7
+
+	tests := []struct {
+		name   string
+		f      Field
+		want   *Field
+		status Status
+		rating Difficulty
+		steps  int
+	}{
+		{
+			name:   "solve field",
+			f:      *testField,
+			want:   testFieldSolved,
+			status: Solved,
+			rating: Medium,
+			steps:  testField.EmptyCells(),
+		},
+		{
+			name:   "empty field",
+			f:      Field{},
+			want:   &Field{},
+			status: Stuck,
+			rating: Expert,
+			steps:  0,
+		},
+		{
+			name:   "field with errors",
+			f:      errorfield,
+			want:   &errorfield,
+			status: Invalid,
+			rating: 0,
+			steps:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SolveResult(context.Background(), tt.f)
+			if !reflect.DeepEqual(got.Solution, tt.want.Grid()) {
+				t.Errorf("SolveResult().Solution = %v, want %v", got.Solution, tt.want)
+			}
+			if got.Status() != tt.status {
+				t.Errorf("SolveResult().Status() = %v, want %v", got.Status(), tt.status)
+			}
+			if got.Rating != tt.rating {
+				t.Errorf("SolveResult().Rating = %v, want %v", got.Rating, tt.rating)
+			}
+			if len(got.Steps) != tt.steps {
+				t.Errorf("SolveResult().Steps has %d steps, want %d", len(got.Steps), tt.steps)
+			}
+		})
+	}
+}
+
+func TestResult_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(SolveResult(context.Background(), *testField))
+	if err != nil {
+		t.Errorf("Result.MarshalJSON() error = %v", err)
+		return
+	}
+	var doc struct {
+		Puzzle   Grid
+		Solution Grid
+		Status   Status
+		Error    *string
+		Steps    []Step
+		Rating   Difficulty
+		Timings  map[string]int64
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Errorf("Result.MarshalJSON() returned invalid JSON: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(&doc.Puzzle, testField.Grid()) || !reflect.DeepEqual(&doc.Solution, testFieldSolved.Grid()) {
+		t.Errorf("Result.MarshalJSON() = %s, wrong puzzle or solution", data)
+	}
+	if doc.Status != Solved || doc.Error != nil || doc.Rating != Medium {
+		t.Errorf("Result.MarshalJSON() = %s, wrong status or rating", data)
+	}
+	if len(doc.Steps) != testField.EmptyCells() {
+		t.Errorf("Result.MarshalJSON() has %d steps, want %d", len(doc.Steps), testField.EmptyCells())
+	}
+	if _, ok := doc.Timings["solve"]; !ok {
+		t.Errorf("Result.MarshalJSON() = %s, missing solve timing", data)
+	}
+}
+
+func TestSolveGridResult(t *testing.T) {
+	g := removeCells(patternGrid(3, 2), 4)
+	got := SolveGridResult(context.Background(), *g)
+	if !reflect.DeepEqual(got.Puzzle, g) || !reflect.DeepEqual(got.Solution, patternGrid(3, 2)) {
+		t.Errorf("SolveGridResult() = %v, %v, want puzzle %v and its solution", got.Puzzle, got.Solution, g)
+	}
+	if got.Status() != Solved || got.Rating != Easy || len(got.Steps) != g.EmptyCells() {
+		t.Errorf("SolveGridResult() has status %v, rating %v and %d steps", got.Status(), got.Rating, len(got.Steps))
+	}
+	data, err := json.Marshal(got)
+	if err != nil || !strings.Contains(string(data), `"puzzle":"`+g.Line()+`"`) {
+		t.Errorf("Result.MarshalJSON() = %s, %v, want puzzle in line format", data, err)
+	}
+}
+
+func TestSolveGridResult_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got := SolveGridResult(ctx, *testField.Grid())
+	if got.Err != context.Canceled || got.Status() != Stuck || len(got.Steps) != 0 {
+		t.Errorf("SolveGridResult() = %v with %d steps, want %v", got.Err, len(got.Steps), context.Canceled)
+	}
+	if _, err := RateGridContext(ctx, *testField.Grid()); err != context.Canceled {
+		t.Errorf("RateGridContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSolveGridResult_Steps(t *testing.T) {
+	// the steps are the ones the rating is based on
+	got := SolveGridResult(context.Background(), *testField.Grid())
+	hint, _ := Hint(*testField)
+	if len(got.Steps) == 0 || got.Steps[0] != *hint {
+		t.Fatalf("SolveGridResult() first step = %v, want hint %v", got.Steps, hint)
+	}
+	hardest := Easy
+	for _, s := range got.Steps {
+		if d := s.Strategy.difficulty(); d > hardest {
+			hardest = d
+		}
+	}
+	if hardest != got.Rating {
+		t.Errorf("SolveGridResult() hardest step is %v, want rating %v", hardest, got.Rating)
+	}
+}
@@ -0,0 +1,116 @@
+package sudoku
+
+// Sandwich is the sum of the numbers between 1 and the largest number in a row or column
+type Sandwich struct {
+	Sum int
+	// Cells are the indices of the cells in Grid.Cells of the row or column in order
+	Cells []int
+}
+
+// Check validates the sum between 1 and the largest number once both are set, see setSum
+func (s *Sandwich) Check(g *Grid) error {
+	first, last := -1, -1
+	for j, i := range s.Cells {
+		if num := g.Cells[i]; num == 1 || num == g.Size {
+			if first == -1 {
+				first = j
+			} else {
+				last = j
+			}
+		}
+	}
+	if last == -1 {
+		return nil
+	}
+	sum, empty := setSum(g, s.Cells[first+1:last])
+	switch {
+	case empty == 0 && sum != s.Sum:
+		return newErrorConstraint(g, SandwichSum, s.Cells[first:last+1], "numbers add up to %d instead of %d", sum, s.Sum)
+	case sum+empty > s.Sum:
+		return newErrorConstraint(g, SandwichSum, s.Cells[first:last+1], "numbers already add up to %d of %d", sum, s.Sum)
+	}
+	return nil
+}
+
+// Propagators returns the clue as a single propagator over its row or column
+func (s *Sandwich) Propagators(g *Grid) []Propagator {
+	return []Propagator{&sandwichPropagator{s: s, size: g.Size}}
+}
+
+// sandwichPropagator prunes a sandwich clue in a grid of the numbers from 1 to size
+type sandwichPropagator struct {
+	s    *Sandwich
+	size int
+}
+
+// Scope returns the row or column of the clue
+func (p *sandwichPropagator) Scope() []int {
+	return p.s.Cells
+}
+
+// Propagate keeps the candidates of all placements of 1 and the largest number with a possible sum between them
+// The numbers between them are different, so they are pruned like a killer cage, see Cage.Propagate.
+func (p *sandwichPropagator) Propagate(values []int, cands []Candidates) bool {
+	n := p.size
+	ends := Candidates(0).With(1).With(n)
+	allowed := make([]Candidates, len(p.s.Cells))
+	possible := false
+	for j, a := range p.s.Cells {
+		for k, b := range p.s.Cells {
+			if j == k || !value(values, cands, a).Has(1) || !value(values, cands, b).Has(n) {
+				continue
+			}
+			lo, hi := j, k
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			placed, ok := p.place(values, cands, lo, hi, ends)
+			if !ok {
+				continue
+			}
+			placed[j], placed[k] = Candidates(0).With(1), Candidates(0).With(n)
+			for l := range allowed {
+				allowed[l] |= placed[l]
+			}
+			possible = true
+		}
+	}
+
+	for j, i := range p.s.Cells {
+		restrict(values, cands, i, allowed[j])
+	}
+	return possible
+}
+
+// place returns the candidates of all cells if 1 and the largest number are at lo and hi
+// It returns false if the numbers between them cannot add up to the sum.
+func (p *sandwichPropagator) place(values []int, cands []Candidates, lo, hi int, ends Candidates) ([]Candidates, bool) {
+	inner := p.s.Cells[lo+1 : hi]
+	placed := make([]Candidates, len(p.s.Cells))
+	for j, i := range p.s.Cells {
+		placed[j] = value(values, cands, i) &^ ends
+	}
+	if len(inner) == 0 {
+		return placed, p.s.Sum == 0
+	}
+
+	trial := append([]Candidates(nil), cands...)
+	for _, i := range inner {
+		if values[i] == EmptyCell {
+			trial[i] &^= ends
+		} else if ends.Has(values[i]) {
+			return nil, false
+		}
+	}
+	cage := Cage{Sum: p.s.Sum, Cells: inner}
+	if !cage.Propagate(values, trial) {
+		return nil, false
+	}
+	for j, i := range inner {
+		if values[i] == EmptyCell && trial[i] == 0 {
+			return nil, false
+		}
+		placed[lo+1+j] = value(values, trial, i)
+	}
+	return placed, true
+}
@@ -0,0 +1,129 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// sandwiches returns the sandwich clues of all rows and columns of the solved grid
+func sandwiches(solved *Grid) []Constraint {
+	var clues []Constraint
+	for _, h := range solved.Houses()[:2*solved.Size] {
+		s := &Sandwich{Cells: h.Cells}
+		inside := false
+		for _, i := range h.Cells {
+			switch num := solved.Cells[i]; {
+			case num == 1 || num == solved.Size:
+				inside = !inside
+			case inside:
+				s.Sum += num
+			}
+		}
+		clues = append(clues, s)
+	}
+	return clues
+}
+
+func TestSandwich_Check(t *testing.T) {
+	sandwich := &Sandwich{Sum: 10, Cells: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+	tests := []struct {
+		name      string
+		line      string
+		wantCells [][2]int
+	}{
+		{name: "empty", line: "........."},
+		{name: "sum", line: "1379....."},
+		{name: "sum between 9 and 1", line: "2.9.3.1.."},
+		{name: "partial sum", line: ".1.3...9."},
+		{name: "wrong sum", line: "2134956..", wantCells: [][2]int{{1, 0}, {2, 0}, {3, 0}, {4, 0}}},
+		{name: "sum exceeded", line: "1.8.4...9", wantCells: [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0}, {8, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			for i, c := range tt.line {
+				g.Cells[i], _ = ParseDigit(c, 9)
+			}
+			err := sandwich.Check(g)
+			if (err != nil) != (tt.wantCells != nil) {
+				t.Errorf("Sandwich.Check() error = %v, want cells %v", err, tt.wantCells)
+				return
+			}
+			var errConstraint ErrorConstraint
+			if err != nil && (!errors.As(err, &errConstraint) || errConstraint.Type() != SandwichSum ||
+				!reflect.DeepEqual(errConstraint.Cells(), tt.wantCells)) {
+				t.Errorf("Sandwich.Check() error = %v, want cells %v", err, tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestSandwich_Propagators(t *testing.T) {
+	tests := []struct {
+		name string
+		sum  int
+		line string
+		want map[int]Candidates
+	}{
+		{
+			name: "no cells between",
+			sum:  0,
+			line: "1........",
+			want: map[int]Candidates{1: Candidates(0).With(9), 2: between(2, 8)},
+		},
+		{
+			name: "largest sum",
+			sum:  35,
+			line: ".........",
+			// 1 and 9 enclose all other numbers, so they are at both ends
+			want: map[int]Candidates{0: Candidates(0).With(1).With(9), 4: between(2, 8), 8: Candidates(0).With(1).With(9)},
+		},
+		{
+			name: "single cell between",
+			sum:  2,
+			line: "1........",
+			want: map[int]Candidates{1: Candidates(0).With(2), 2: Candidates(0).With(9), 3: between(2, 8)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			cands := make([]Candidates, len(g.Cells))
+			for i, c := range tt.line {
+				g.Cells[i], _ = ParseDigit(c, 9)
+			}
+			for i := range cands {
+				if g.Cells[i] == EmptyCell {
+					cands[i] = AllCandidates(9)
+					for j := 0; j < 9; j++ {
+						if g.Cells[j] != EmptyCell && i < 9 {
+							cands[i] = cands[i].Without(g.Cells[j])
+						}
+					}
+				}
+			}
+			s := &Sandwich{Sum: tt.sum, Cells: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+			if !propagateConstraint(g, s, cands) {
+				t.Fatalf("Sandwich.Propagators() failed")
+			}
+			for i, want := range tt.want {
+				if cands[i] != want {
+					t.Errorf("Sandwich.Propagators() cell %d = %v, want %v", i, cands[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveGrid_Sandwich(t *testing.T) {
+	g, _ := ParseGrid(".....................8.....4............9....3...........................7.......")
+	g.Constraints = sandwiches(testFieldSolved.Grid())
+	got, err := SolveGrid(*g, nil)
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	if want := testFieldSolved.Grid(); got.Line() != want.Line() {
+		t.Errorf("SolveGrid() = %s, want %s", got.Line(), want.Line())
+	}
+}
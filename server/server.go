@@ -0,0 +1,285 @@
+// Package server exposes the sudoku solver as HTTP JSON API
+//
+// All endpoints accept POST requests with a JSON body, e.g.:
+// {"puzzle": "..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3.."}
+// The puzzle can also be passed as array of rows, puzzles of other sizes than 9x9
+// use the letters A to Z for the numbers 10 to 35.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// Options configure the limits of the server
+type Options struct {
+	// MaxBodySize is the maximum size of a request body in bytes, defaults to 64KiB
+	MaxBodySize int64
+	// Timeout is the maximum time to handle a single request, defaults to 10 seconds
+	Timeout time.Duration
+	// MaxCount is the maximum number of solutions counted by /count, defaults to 1000
+	MaxCount int
+}
+
+// server handles the API requests
+type server struct {
+	opts Options
+}
+
+// request is the JSON body of all requests
+// Only the fields needed by the endpoint are used.
+type request struct {
+	Puzzle *sudoku.Grid `json:"puzzle"`
+	// Limit is the maximum number of solutions to count
+	Limit int `json:"limit"`
+	// Difficulty of the generated sudoku, any difficulty if empty
+	Difficulty sudoku.Difficulty `json:"difficulty"`
+	// Seed for the generator, random if not set
+	Seed *int64 `json:"seed"`
+}
+
+// validation is the response of /validate
+type validation struct {
+	Valid     bool     `json:"valid"`
+	Solved    bool     `json:"solved"`
+	Error     string   `json:"error,omitempty"`
+	Conflicts [][2]int `json:"conflicts,omitempty"`
+}
+
+// New creates the handler for all endpoints of the API
+func New(opts Options) http.Handler {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = 64 << 10
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxCount <= 0 {
+		opts.MaxCount = 1000
+	}
+	s := &server{opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solve", s.post(s.solve))
+	mux.HandleFunc("/validate", s.post(s.validate))
+	mux.HandleFunc("/hint", s.post(s.hint))
+	mux.HandleFunc("/rate", s.post(s.rate))
+	mux.HandleFunc("/generate", s.post(s.generate))
+	mux.HandleFunc("/count", s.post(s.count))
+	return mux
+}
+
+// handler handles a decoded request and returns the status code and response body
+type handler func(ctx context.Context, req request) (int, interface{})
+
+// post decodes the request body, runs the handler with the request timeout
+// and encodes its response
+func (s *server) post(h handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+
+		var req request
+		body := http.MaxBytesReader(w, r.Body, s.opts.MaxBodySize)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body is larger than %d bytes", tooLarge.Limit))
+				return
+			}
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %s", err))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), s.opts.Timeout)
+		defer cancel()
+
+		// the handlers pass the context on to the solver, so they stop soon after a timeout
+		// and their response is dropped
+		type response struct {
+			code int
+			body interface{}
+		}
+		done := make(chan response, 1)
+		go func() {
+			code, body := h(ctx, req)
+			done <- response{code, body}
+		}()
+		select {
+		case resp := <-done:
+			// a handler stopped by the timeout may report the solver's error instead
+			if err := ctx.Err(); err != nil {
+				writeError(w, http.StatusServiceUnavailable, err)
+				return
+			}
+			writeJSON(w, resp.code, resp.body)
+		case <-ctx.Done():
+			writeError(w, http.StatusServiceUnavailable, ctx.Err())
+		}
+	}
+}
+
+// solve solves the puzzle with the logical solver
+// The response is the result with all steps, see sudoku.Result.
+// If the solver gets stuck, the solution is searched and the steps end where it got stuck.
+func (s *server) solve(ctx context.Context, req request) (int, interface{}) {
+	if req.Puzzle == nil {
+		return missingPuzzle()
+	}
+	r := sudoku.SolveGridResult(ctx, *req.Puzzle)
+	switch {
+	case r.Err == nil:
+	case errors.Is(r.Err, sudoku.ErrStuck):
+		solved, err := sudoku.SolveGridModel(ctx, *req.Puzzle)
+		if err != nil {
+			r.Err = err
+			return errorStatus(err), r
+		}
+		r.Solution, r.Err = solved, nil
+	case req.Puzzle.Check() != nil:
+		// the grid is malformed or violates the rules
+		return http.StatusBadRequest, r
+	default:
+		return errorStatus(r.Err), r
+	}
+	return http.StatusOK, r
+}
+
+// validate checks the puzzle against the sudoku rules
+// Invalid puzzles are no error, the response describes the conflicts.
+func (s *server) validate(ctx context.Context, req request) (int, interface{}) {
+	if req.Puzzle == nil {
+		return missingPuzzle()
+	}
+	g := req.Puzzle
+	v := validation{Valid: true}
+	if err := g.Check(); err != nil {
+		v.Valid, v.Error = false, err.Error()
+		for i, marked := range g.Conflicts() {
+			if marked {
+				v.Conflicts = append(v.Conflicts, [2]int{i % g.Size, i / g.Size})
+			}
+		}
+	}
+	v.Solved = v.Valid && g.EmptyCells() == 0
+	return http.StatusOK, v
+}
+
+// hint returns the next step the solver would make
+func (s *server) hint(ctx context.Context, req request) (int, interface{}) {
+	if err := checkPuzzle(req); err != nil {
+		return http.StatusBadRequest, errorBody(err)
+	}
+	step, err := sudoku.HintGrid(*req.Puzzle)
+	if err != nil {
+		return errorStatus(err), errorBody(err)
+	}
+	return http.StatusOK, step
+}
+
+// rate returns the difficulty of the puzzle
+func (s *server) rate(ctx context.Context, req request) (int, interface{}) {
+	if err := checkPuzzle(req); err != nil {
+		return http.StatusBadRequest, errorBody(err)
+	}
+	rating, err := sudoku.RateGridContext(ctx, *req.Puzzle)
+	if err != nil {
+		return errorStatus(err), errorBody(err)
+	}
+	return http.StatusOK, map[string]interface{}{"rating": rating}
+}
+
+// generate creates a new puzzle with a unique solution
+func (s *server) generate(ctx context.Context, req request) (int, interface{}) {
+	if _, err := req.Difficulty.MarshalText(); req.Difficulty != 0 && err != nil {
+		return http.StatusBadRequest, errorBody(err)
+	}
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	f, err := sudoku.Generate(ctx, rand.New(rand.NewSource(seed)), req.Difficulty)
+	if err != nil {
+		return errorStatus(err), errorBody(err)
+	}
+	rating, _ := sudoku.Rate(*f)
+	return http.StatusOK, map[string]interface{}{
+		"puzzle": f,
+		"rating": rating,
+		"seed":   seed,
+	}
+}
+
+// count counts the solutions of the puzzle up to the requested limit
+func (s *server) count(ctx context.Context, req request) (int, interface{}) {
+	if err := checkPuzzle(req); err != nil {
+		return http.StatusBadRequest, errorBody(err)
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > s.opts.MaxCount {
+		limit = s.opts.MaxCount
+	}
+	count, err := sudoku.CountGridSolutions(ctx, *req.Puzzle, limit)
+	if err != nil {
+		return errorStatus(err), errorBody(err)
+	}
+	return http.StatusOK, map[string]interface{}{
+		"count":  count,
+		"limit":  limit,
+		"unique": count == 1,
+	}
+}
+
+// checkPuzzle returns an error if the puzzle is missing, malformed or violates the rules
+func checkPuzzle(req request) error {
+	if req.Puzzle == nil {
+		return errors.New("missing puzzle")
+	}
+	if err := req.Puzzle.Check(); err != nil {
+		return fmt.Errorf("field is invalid: %w", err)
+	}
+	return nil
+}
+
+// errorStatus returns the status code for an error of the sudoku package
+// Fields violating the rules are bad requests, fields the solver cannot
+// handle are unprocessable. Handlers check malformed fields with checkPuzzle first.
+func errorStatus(err error) int {
+	var errSudoku sudoku.ErrorSudoku
+	var errConstraint sudoku.ErrorConstraint
+	switch {
+	case errors.As(err, &errSudoku), errors.As(err, &errConstraint):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusUnprocessableEntity
+}
+
+func missingPuzzle() (int, interface{}) {
+	return http.StatusBadRequest, errorBody(errors.New("missing puzzle"))
+}
+
+func errorBody(err error) interface{} {
+	return map[string]string{"error": err.Error()}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorBody(err))
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
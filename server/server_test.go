@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+const (
+	testPuzzle   = `"..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3.."`
+	testSolution = `"483921657967345821251876493548132976729564138136798245372689514814253769695417382"`
+	// testInvalid has two 3 in the first row
+	testInvalid = `"..3.2.6.39..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3.."`
+	// testStuck has multiple solutions, the last three rows are empty
+	testStuck = `"..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82............................."`
+	// testExpert has a unique solution, but needs more than singles
+	testExpert = `"..9748...7.........2.1.9.....7...24..64.1.59..98...3.....8.3.2.........6...2759.."`
+	// testNoSolution leaves no number for the last cell of the first row
+	testNoSolution = `"12345678.........9..............................................................."`
+)
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		// wantBody are JSON keys with the expected raw values
+		wantBody map[string]string
+	}{
+		{
+			name:     "solve",
+			path:     "/solve",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"status": `"solved"`, "solution": testSolution},
+		},
+		{
+			name:     "solve 6x6",
+			path:     "/solve",
+			body:     `{"puzzle":".234.645.123.345.156.234.456.261.345"}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"status": `"solved"`, "solution": `"123456456123234561561234345612612345"`},
+		},
+		{
+			name:     "validate 4x4 rows",
+			path:     "/validate",
+			body:     `{"puzzle":[[1,1,0,0],[0,0,0,0],[0,0,0,0],[0,0,0,0]]}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"valid": "false", "conflicts": "[[0,0],[1,0]]"},
+		},
+		{
+			name:     "solve invalid",
+			path:     "/solve",
+			body:     `{"puzzle":` + testInvalid + `}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: map[string]string{"status": `"invalid"`},
+		},
+		{
+			name:     "solve beyond singles",
+			path:     "/solve",
+			body:     `{"puzzle":` + testExpert + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"status": `"solved"`, "rating": `"expert"`},
+		},
+		{
+			name:     "solve multiple solutions",
+			path:     "/solve",
+			body:     `{"puzzle":` + testStuck + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"status": `"solved"`},
+		},
+		{
+			name:     "solve without solution",
+			path:     "/solve",
+			body:     `{"puzzle":` + testNoSolution + `}`,
+			wantCode: http.StatusUnprocessableEntity,
+			wantBody: map[string]string{"status": `"stuck"`},
+		},
+		{
+			name:     "solve without puzzle",
+			path:     "/solve",
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: map[string]string{"error": `"missing puzzle"`},
+		},
+		{
+			name:     "malformed puzzle",
+			path:     "/solve",
+			body:     `{"puzzle":"123"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "malformed json",
+			path:     "/rate",
+			body:     `{"puzzle":`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "wrong method",
+			method:   http.MethodGet,
+			path:     "/solve",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "body too large",
+			path:     "/solve",
+			body:     `{"puzzle":` + testPuzzle + `,"padding":"` + strings.Repeat(" ", 1024) + `"}`,
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "validate",
+			path:     "/validate",
+			body:     `{"puzzle":` + testSolution + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"valid": "true", "solved": "true"},
+		},
+		{
+			name:     "validate invalid",
+			path:     "/validate",
+			body:     `{"puzzle":` + testInvalid + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"valid": "false", "solved": "false", "conflicts": "[[2,0],[8,0]]"},
+		},
+		{
+			name:     "hint",
+			path:     "/hint",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"strategy": `"hidden single in square"`},
+		},
+		{
+			name:     "hint solved",
+			path:     "/hint",
+			body:     `{"puzzle":` + testSolution + `}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "hint invalid",
+			path:     "/hint",
+			body:     `{"puzzle":` + testInvalid + `}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "rate",
+			path:     "/rate",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"rating": `"easy"`},
+		},
+		{
+			name:     "count",
+			path:     "/count",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"count": "1", "unique": "true"},
+		},
+		{
+			name:     "count with limit",
+			path:     "/count",
+			body:     `{"puzzle":` + testStuck + `,"limit":2}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"count": "2", "limit": "2", "unique": "false"},
+		},
+		{
+			name:     "count 4x4",
+			path:     "/count",
+			body:     `{"puzzle":"1..............."}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"count": "72", "unique": "false"},
+		},
+		{
+			name:     "count invalid",
+			path:     "/count",
+			body:     `{"puzzle":` + testInvalid + `}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "generate",
+			path:     "/generate",
+			body:     `{"seed":1,"difficulty":"easy"}`,
+			wantCode: http.StatusOK,
+			wantBody: map[string]string{"rating": `"easy"`, "seed": "1"},
+		},
+		{
+			name:     "generate unknown difficulty",
+			path:     "/generate",
+			body:     `{"difficulty":"impossible"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "generate unsolvable",
+			path:     "/generate",
+			body:     `{"difficulty":"unsolvable"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "generate difficulty number",
+			path:     "/generate",
+			body:     `{"difficulty":9}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	srv := httptest.NewServer(New(Options{MaxBodySize: 1024}))
+	defer srv.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req, err := http.NewRequest(method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("%s %s status = %d, want %d", method, tt.path, resp.StatusCode, tt.wantCode)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("%s %s Content-Type = %s, want application/json", method, tt.path, ct)
+			}
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("%s %s invalid JSON response: %s", method, tt.path, err)
+			}
+			for key, want := range tt.wantBody {
+				if got := string(body[key]); got != want {
+					t.Errorf("%s %s %s = %s, want %s", method, tt.path, key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_Malformed(t *testing.T) {
+	s := &server{opts: Options{MaxCount: 10}}
+	// grids with a wrong number of cells cannot be decoded, but must not reach the solver either
+	malformed := request{Puzzle: &sudoku.Grid{Size: 9, BoxWidth: 3, BoxHeight: 3, Cells: make([]int, 80)}}
+
+	tests := []struct {
+		name string
+		h    handler
+		req  request
+	}{
+		{name: "solve", h: s.solve, req: malformed},
+		{name: "hint", h: s.hint, req: malformed},
+		{name: "rate", h: s.rate, req: malformed},
+		{name: "count", h: s.count, req: malformed},
+		{name: "generate unknown difficulty", h: s.generate, req: request{Difficulty: sudoku.Difficulty(9)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, body := tt.h(context.Background(), tt.req); code != http.StatusBadRequest {
+				t.Errorf("handler status = %d, want %d: %v", code, http.StatusBadRequest, body)
+			}
+		})
+	}
+}
+
+func TestServer_Timeout(t *testing.T) {
+	handler := New(Options{Timeout: time.Nanosecond})
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		// an empty field has too many solutions to count in time
+		{name: "count", path: "/count", body: `{"puzzle":"` + strings.Repeat(".", 81) + `"}`},
+		// the solver stops early, which must not be reported as stuck
+		{name: "solve", path: "/solve", body: `{"puzzle":` + testExpert + `}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusServiceUnavailable {
+				t.Errorf("POST %s status = %d, want %d", tt.path, rec.Code, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/KeKsBoTer/sudoku"
+)
+
+// Stream streams the steps of the solver as server-sent events
+//
+// GET /stream?puzzle=<line>&delay=<ms>&step=<bool> solves the puzzle and sends the events:
+// start: {"session": "...", "puzzle": "..."}
+// step: {"index": 1, "step": {...}, "field": "..."} for every cell set by the solver
+// done: {"status": "solved", "solution": "...", "error": "..."}
+// Between two steps the stream waits for delay milliseconds or, if step is set,
+// until POST /stream/next?session=<session> is called.
+type Stream struct {
+	// Delay between two steps if the request sets no delay
+	Delay time.Duration
+	// Step makes streams wait for /stream/next if the request does not set step
+	Step bool
+
+	mu sync.Mutex
+	// sessions are the channels to continue the running streams
+	sessions map[string]chan struct{}
+}
+
+// stepEvent is the data of a step event
+type stepEvent struct {
+	Index int         `json:"index"`
+	Step  sudoku.Step `json:"step"`
+	Field sudoku.Grid `json:"field"`
+}
+
+// doneEvent is the data of the done event
+type doneEvent struct {
+	Status   sudoku.Status `json:"status"`
+	Solution *sudoku.Grid  `json:"solution,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/stream":
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		s.stream(w, r)
+	case "/stream/next":
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		s.next(w, r)
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+// stream solves the puzzle of the request and sends every step
+func (s *Stream) stream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	g, err := sudoku.ParseGrid(query.Get("puzzle"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid puzzle: %s", err))
+		return
+	}
+	delay, step := s.Delay, s.Step
+	if v := query.Get("delay"); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid delay '%s'", v))
+			return
+		}
+		delay = time.Duration(ms) * time.Millisecond
+	}
+	if v := query.Get("step"); v != "" {
+		if step, err = strconv.ParseBool(v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid step '%s'", v))
+			return
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	id, next := s.open()
+	defer s.close(id)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	send := func(event string, data interface{}) {
+		b, _ := json.Marshal(data)
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+		flusher.Flush()
+	}
+
+	ctx := r.Context()
+	send("start", map[string]interface{}{"session": id, "puzzle": g})
+	index := 0
+	// the solver stops once the client is gone
+	solution, err := sudoku.SolveGridContext(ctx, *g, func(updated sudoku.Grid, st sudoku.Step) {
+		index++
+		send("step", stepEvent{Index: index, Step: st, Field: updated})
+		if step {
+			select {
+			case <-next:
+			case <-ctx.Done():
+			}
+		} else if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			}
+		}
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	done := doneEvent{Status: sudoku.Result{Err: err}.Status(), Solution: solution}
+	if err != nil {
+		done.Error = err.Error()
+	}
+	send("done", done)
+}
+
+// next continues the stream of a session waiting for the next step
+func (s *Stream) next(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	next, ok := s.sessions[r.URL.Query().Get("session")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("unknown session"))
+		return
+	}
+	// a step requested before the stream waits for it is kept
+	select {
+	case next <- struct{}{}:
+	default:
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// open creates a new session
+func (s *Stream) open() (string, chan struct{}) {
+	b := make([]byte, 8)
+	rand.Read(b)
+	id := hex.EncodeToString(b)
+	next := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions == nil {
+		s.sessions = make(map[string]chan struct{})
+	}
+	s.sessions[id] = next
+	return id, next
+}
+
+// close removes a finished session
+func (s *Stream) close(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
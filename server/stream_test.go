@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// event is a single server-sent event
+type event struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event of the stream
+func readEvent(r *bufio.Reader) (event, error) {
+	var e event
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return e, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return e, nil
+		case strings.HasPrefix(line, "event: "):
+			e.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	puzzle := strings.Trim(testPuzzle, `"`)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantSteps int
+		wantDone  string
+	}{
+		{
+			name:      "solve",
+			query:     "puzzle=" + puzzle + "&delay=0",
+			wantCode:  http.StatusOK,
+			wantSteps: 49,
+			wantDone:  `{"status":"solved","solution":` + testSolution + `}`,
+		},
+		{
+			name:      "stuck",
+			query:     "puzzle=" + strings.Trim(testStuck, `"`) + "&delay=0",
+			wantCode:  http.StatusOK,
+			wantSteps: 0,
+		},
+		{
+			name:     "invalid puzzle",
+			query:    "puzzle=123",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid delay",
+			query:    "puzzle=" + puzzle + "&delay=-1",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	srv := httptest.NewServer(&Stream{})
+	defer srv.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/stream?" + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("GET /stream status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			r := bufio.NewReader(resp.Body)
+			steps := 0
+			var done string
+			for {
+				e, err := readEvent(r)
+				if err != nil {
+					break
+				}
+				switch e.name {
+				case "step":
+					steps++
+				case "done":
+					done = e.data
+				}
+			}
+			if tt.wantSteps != 0 && steps != tt.wantSteps {
+				t.Errorf("GET /stream sent %d steps, want %d", steps, tt.wantSteps)
+			}
+			if done == "" {
+				t.Errorf("GET /stream sent no done event")
+			}
+			if tt.wantDone != "" && done != tt.wantDone {
+				t.Errorf("GET /stream done = %s, want %s", done, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestStream_Step(t *testing.T) {
+	srv := httptest.NewServer(&Stream{Step: true})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/stream?puzzle=" + url.QueryEscape(strings.Trim(testPuzzle, `"`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	r := bufio.NewReader(resp.Body)
+
+	e, err := readEvent(r)
+	if err != nil || e.name != "start" {
+		t.Fatalf("first event = %v, %v, want start", e, err)
+	}
+	var start struct{ Session string }
+	json.Unmarshal([]byte(e.data), &start)
+
+	// every call of next sends exactly one more step
+	for i := 1; i <= 3; i++ {
+		if e, err = readEvent(r); err != nil || e.name != "step" {
+			t.Fatalf("event = %v, %v, want step", e, err)
+		}
+		var step stepEvent
+		json.Unmarshal([]byte(e.data), &step)
+		if step.Index != i {
+			t.Errorf("step index = %d, want %d", step.Index, i)
+		}
+		next, err := http.Post(srv.URL+"/stream/next?session="+start.Session, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next.Body.Close()
+		if next.StatusCode != http.StatusNoContent {
+			t.Errorf("POST /stream/next status = %d, want %d", next.StatusCode, http.StatusNoContent)
+		}
+	}
+
+	next, err := http.Post(srv.URL+"/stream/next?session=unknown", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next.Body.Close()
+	if next.StatusCode != http.StatusNotFound {
+		t.Errorf("POST /stream/next with unknown session status = %d, want %d", next.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// files contains the single page web front-end
+//
+//go:embed web
+var files embed.FS
+
+// Web creates the handler for the web front-end and the endpoints it uses
+// The page can validate and solve sudokus with the API and animate the solver
+// with the stream.
+func Web(opts Options, stream *Stream) http.Handler {
+	api := New(opts)
+	root, _ := fs.Sub(files, "web")
+
+	mux := http.NewServeMux()
+	mux.Handle("/validate", api)
+	mux.Handle("/solve", api)
+	mux.Handle("/stream", stream)
+	mux.Handle("/stream/next", stream)
+	mux.Handle("/", http.FileServer(http.FS(root)))
+	return mux
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWeb(t *testing.T) {
+	puzzle := strings.Trim(testPuzzle, `"`)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantType string
+		wantBody string
+	}{
+		{
+			name:     "index",
+			method:   http.MethodGet,
+			path:     "/",
+			wantCode: http.StatusOK,
+			wantType: "text/html; charset=utf-8",
+			wantBody: "<title>Sudoku</title>",
+		},
+		{
+			name:     "validate",
+			method:   http.MethodPost,
+			path:     "/validate",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantType: "application/json",
+			wantBody: `"valid":true`,
+		},
+		{
+			name:     "solve",
+			method:   http.MethodPost,
+			path:     "/solve",
+			body:     `{"puzzle":` + testPuzzle + `}`,
+			wantCode: http.StatusOK,
+			wantType: "application/json",
+			wantBody: `"solution":` + testSolution,
+		},
+		{
+			name:     "animate",
+			method:   http.MethodGet,
+			path:     "/stream?delay=0&puzzle=" + puzzle,
+			wantCode: http.StatusOK,
+			wantType: "text/event-stream",
+			wantBody: "event: done",
+		},
+		{
+			name:     "only used endpoints",
+			method:   http.MethodPost,
+			path:     "/generate",
+			body:     `{}`,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	handler := Web(Options{}, &Stream{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, rec.Code, tt.wantCode)
+			}
+			if ct := rec.Header().Get("Content-Type"); tt.wantType != "" && ct != tt.wantType {
+				t.Errorf("%s %s Content-Type = %s, want %s", tt.method, tt.path, ct, tt.wantType)
+			}
+			if body := rec.Body.String(); !strings.Contains(body, tt.wantBody) {
+				t.Errorf("%s %s body does not contain %s:\n%s", tt.method, tt.path, tt.wantBody, body)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package sudoku
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
+// ErrStuck is returned by the solver if it cannot set any more cells
+var ErrStuck = errors.New("Stuck :(")
+
 // SolverField is a array to manage the possible numbers for all fields
 type SolverField [9][9]*Possibilities
 
@@ -52,135 +57,134 @@ func (f *Field) PossibilitiesInSquare(x, y int) *Possibilities {
 // UpdateFunc is called when field is updated
 type UpdateFunc func(f Field)
 
+// StepFunc is called when the solver sets a cell
+type StepFunc func(f Field, s Step)
+
 // Solve solves sudoku field
 func Solve(f Field, onUpdate UpdateFunc) (*Field, error) {
+	return SolveSteps(f, func(f Field, s Step) {
+		if onUpdate != nil {
+			onUpdate(f)
+		}
+	})
+}
+
+// SolveSteps solves sudoku field like Solve
+// but tells the callback which cell was set and why
+func SolveSteps(f Field, onStep StepFunc) (*Field, error) {
 
 	// check if the enterd field is correct
 	if err := f.Check(); err != nil {
-		return &f, fmt.Errorf("field is invalid: %v", err)
+		return &f, fmt.Errorf("field is invalid: %w", err)
 	}
 
-	var check SolverField
+	solved, err := SolveGrid(*f.Grid(), func(g Grid, s Step) {
+		if onStep != nil {
+			updated, _ := g.Field()
+			onStep(*updated, s)
+		}
+	})
+	field, _ := solved.Field()
+	return field, err
+}
+
+// GridStepFunc is called when the solver sets a cell of a grid
+type GridStepFunc func(g Grid, s Step)
+
+// SolveGrid solves a grid of any size like SolveSteps
+// The candidates of the empty cells are the domains of the grid's model, see Grid.Model.
+func SolveGrid(g Grid, onStep GridStepFunc) (*Grid, error) {
+	return SolveGridContext(context.Background(), g, onStep)
+}
+
+// SolveGridContext solves a grid like SolveGrid, but stops with the error of the context once it is done
+func SolveGridContext(ctx context.Context, g Grid, onStep GridStepFunc) (*Grid, error) {
+	g = *g.Clone()
+
+	// check if the enterd grid is correct
+	if err := g.Check(); err != nil {
+		return &g, fmt.Errorf("field is invalid: %w", err)
+	}
+
+	err := solveModel(ctx, g.Model(), g.Houses(), g.Size, func(i, num int, s Strategy) {
+		g.Cells[i] = num
+		if onStep != nil {
+			onStep(*g.Clone(), Step{X: i % g.Size, Y: i / g.Size, Num: num, Strategy: s})
+		}
+	})
+	return &g, err
+}
+
+// solveModel sets naked singles and hidden singles of the houses until all variables of the model are set
+// Every set variable is propagated to all propagators of the model, onSet is called after it.
+// It returns ErrStuck if no single is left or the model has no solution anymore.
+func solveModel(ctx context.Context, m *Model, houses []House, max int, onSet func(v, num int, s Strategy)) error {
+	if !m.Propagate() {
+		return ErrStuck
+	}
 	var updated bool
 
-	// function to update cell
-	setCell := func(x, y, num int) {
-		f[y][x] = num
-		f.updatePossibilities(&check)
-		if onUpdate != nil {
-			onUpdate(f)
+	// function to set a variable, it returns false if the model has no solution anymore
+	set := func(v, num int, s Strategy) bool {
+		if !m.Set(v, num) {
+			return false
 		}
+		onSet(v, num, s)
 		updated = true
+		return true
 	}
 
-	empty := f.EmptyCells()
-	for empty > 0 {
+	for countOpen(m.Values) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		updated = false
-		f.updatePossibilities(&check)
 
-		for i := 0; i < 9; i++ {
-			for j := 0; j < 9; j++ {
-				if ok, num := check[i][j].OnlyOne(); ok {
-					setCell(j, i, num)
-				}
+		for v, d := range m.Domains {
+			if num, ok := d.Single(); ok && !set(v, num, NakedSingle) {
+				return ErrStuck
 			}
 		}
-		// check if number can only places at one call in row
-		for i := 0; i < 9; i++ {
+
+		// check if number can only placed at one cell in a row, column, box or extra house
+		for _, h := range houses {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 		num:
-			for n := 1; n <= 9; n++ {
+			for n := 1; n <= max; n++ {
 				pos := -1
-				for j := 0; j < 9; j++ {
-					if f[i][j] == 0 && check[i][j].IsPossible(n) {
+				for _, v := range h.Cells {
+					if m.Values[v] == EmptyCell && m.Domains[v].Has(n) {
 						if pos != -1 {
 							continue num
 						}
-						pos = j
-					}
-				}
-				if pos != -1 {
-					setCell(pos, i, n)
-				}
-			}
-		}
-
-		// check if number can only places at one call in column
-		for i := 0; i < 9; i++ {
-		num2:
-			for n := 1; n <= 9; n++ {
-				pos := -1
-				for j := 0; j < 9; j++ {
-					if f[j][i] == 0 && check[j][i].IsPossible(n) {
-						if pos != -1 {
-							continue num2
-						}
-						pos = j
+						pos = v
 					}
 				}
-				if pos != -1 {
-					setCell(i, pos, n)
-				}
-			}
-		}
-
-		for i := 0; i < 3; i++ {
-			for j := 0; j < 3; j++ {
-			num3:
-				for n := 1; n <= 9; n++ {
-					posX, posY := -1, -1
-
-					for y := 0; y < 3; y++ {
-						for x := 0; x < 3; x++ {
-							if f[i*3+y][j*3+x] == 0 && check[i*3+y][j*3+x].IsPossible(n) {
-								if posX != -1 {
-									continue num3
-								}
-								posX, posY = j*3+x, i*3+y
-							}
-						}
-					}
-					if posX != -1 {
-						setCell(posX, posY, n)
-					}
+				if pos != -1 && !set(pos, n, hiddenSingle(h.Type)) {
+					return ErrStuck
 				}
 			}
 		}
 
 		// solver is stuck if it cannot set new cells
 		if !updated {
-			return &f, fmt.Errorf("Stuck :(")
+			return ErrStuck
 		}
-
-		empty = f.EmptyCells()
 	}
-	return &f, nil
+	return nil
 }
 
-// updates possible numbers for all cells
-func (f *Field) updatePossibilities(c *SolverField) {
-	var rows [9]*Possibilities
-	var cols [9]*Possibilities
-	var squares [3][3]*Possibilities
-	for i := 0; i < 9; i++ {
-		rows[i] = f.PossibilitiesInRow(i)
-		cols[i] = f.PossibilitiesInColumn(i)
-		squares[i/3][i-(i/3)*3] = f.PossibilitiesInSquare((i-(i/3)*3)*3, (i/3)*3)
-	}
-
-	for i := 0; i < 9; i++ {
-		for j := 0; j < 9; j++ {
-			if f[i][j] == 0 {
-				c[i][j] = mergePossibilities(
-					rows[i],
-					cols[j],
-					squares[i/3][j/3],
-				)
-			} else {
-				// Field is allready set, no numbers are possible
-				c[i][j] = new(Possibilities)
-			}
+// countOpen returns the number of open variables
+func countOpen(values []int) int {
+	count := 0
+	for _, num := range values {
+		if num == EmptyCell {
+			count++
 		}
 	}
+	return count
 }
 
 // String turns the object into a human readable string for debugging
@@ -0,0 +1,238 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// migrations upgrade the JSON document of a save file by one version
+// migrations[i] turns version i+1 into version i+2,
+// add a migration whenever the format changes.
+var migrations = []func(doc map[string]json.RawMessage) error{
+	migrateFieldToGrid,
+}
+
+// StateVersion returns the version of the save file format written by SaveState
+func StateVersion() int {
+	return len(migrations) + 1
+}
+
+// State is an in-progress sudoku which can be saved and resumed later
+// The regions and extra houses of variants are saved, grids with constraints cannot be saved.
+type State struct {
+	// Givens is the grid the solving started with
+	Givens *Grid
+	// Grid is the current grid
+	// Its marks are the possible numbers of every cell, including manual eliminations.
+	Grid *Grid
+	// Steps are all cells set by the solver so far
+	Steps []Step
+	// Saved is the time the state was saved
+	Saved time.Time
+}
+
+// stateJSON is the JSON document of a state
+// e.g.:
+// {"boxes":[3,3],"givens":"7....48...","grid":"7..2.48...",
+// "candidates":[[],[2,5,6],...],"steps":[{"x":3,"y":0,"number":2,"strategy":"naked single"}],
+// "saved":"2006-01-02T15:04:05Z"}
+type stateJSON struct {
+	// Boxes are the width and height of the boxes
+	Boxes      [2]int       `json:"boxes"`
+	Regions    []int        `json:"regions,omitempty"`
+	Extra      []House      `json:"extra,omitempty"`
+	Givens     string       `json:"givens"`
+	Grid       string       `json:"grid"`
+	Candidates []Candidates `json:"candidates,omitempty"`
+	Steps      []Step       `json:"steps"`
+	Saved      time.Time    `json:"saved"`
+}
+
+// NewState creates the state of a grid which is being solved from givens
+// The candidates become the marks of the saved grid, so manual eliminations are kept.
+func NewState(givens, g *Grid, cands []Candidates, steps []Step) *State {
+	current := g.Clone()
+	current.Marks = append([]Candidates(nil), cands...)
+	return &State{
+		Givens: givens.Clone(),
+		Grid:   current,
+		Steps:  steps,
+	}
+}
+
+// MarshalJSON writes the layout of the grids once and their cells in line format
+// It fails for grids with constraints, which would be lost.
+func (s State) MarshalJSON() ([]byte, error) {
+	if len(s.Givens.Constraints) > 0 || len(s.Grid.Constraints) > 0 {
+		return nil, fmt.Errorf("Sudokus with variant constraints cannot be saved")
+	}
+	return json.Marshal(stateJSON{
+		Boxes:      [2]int{s.Grid.BoxWidth, s.Grid.BoxHeight},
+		Regions:    s.Grid.Regions,
+		Extra:      s.Grid.Extra,
+		Givens:     s.Givens.Line(),
+		Grid:       s.Grid.Line(),
+		Candidates: s.Grid.Marks,
+		Steps:      s.Steps,
+		Saved:      s.Saved,
+	})
+}
+
+// UnmarshalJSON reads a state written by State.MarshalJSON
+func (s *State) UnmarshalJSON(data []byte) error {
+	var doc stateJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	givens, err := doc.grid(doc.Givens)
+	if err != nil {
+		return fmt.Errorf("invalid givens: %w", err)
+	}
+	current, err := doc.grid(doc.Grid)
+	if err != nil {
+		return fmt.Errorf("invalid grid: %w", err)
+	}
+	current.Marks = doc.Candidates
+	if err := current.Check(); err != nil {
+		return fmt.Errorf("invalid grid: %w", err)
+	}
+	*s = State{Givens: givens, Grid: current, Steps: doc.Steps, Saved: doc.Saved}
+	return nil
+}
+
+// grid reads the cells of a grid in line format with the layout of the document
+func (doc *stateJSON) grid(line string) (*Grid, error) {
+	width, height := doc.Boxes[0], doc.Boxes[1]
+	if width < 1 || height < 1 || width*height > MaxSize {
+		return nil, fmt.Errorf("Boxes of %dx%d cells are not supported", width, height)
+	}
+	g := NewGridBoxes(width, height)
+	if len(line) != len(g.Cells) {
+		return nil, fmt.Errorf("Line has %d characters instead of %d", len(line), len(g.Cells))
+	}
+	for i, c := range strings.ToUpper(line) {
+		num, err := ParseDigit(c, g.Size)
+		if err != nil {
+			return nil, fmt.Errorf("%s at position %d", err, i+1)
+		}
+		g.Cells[i] = num
+	}
+	g.Regions, g.Extra = doc.Regions, doc.Extra
+	return g, nil
+}
+
+// migrateFieldToGrid turns a 9x9 field of version 1 into a grid with 3x3 boxes
+// The current field is renamed to grid and its candidates
+// are listed cell by cell instead of in 9 rows.
+func migrateFieldToGrid(doc map[string]json.RawMessage) error {
+	if field, ok := doc["field"]; ok {
+		doc["grid"] = field
+		delete(doc, "field")
+	}
+	doc["boxes"] = json.RawMessage("[3,3]")
+	if data, ok := doc["candidates"]; ok {
+		var rows [][]json.RawMessage
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return err
+		}
+		var cells []json.RawMessage
+		for _, row := range rows {
+			cells = append(cells, row...)
+		}
+		flat, err := json.Marshal(cells)
+		if err != nil {
+			return err
+		}
+		doc["candidates"] = flat
+	}
+	return nil
+}
+
+// SaveState writes the state as versioned JSON document
+func SaveState(w io.Writer, s *State) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	doc["version"] = json.RawMessage(fmt.Sprint(StateVersion()))
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// LoadState reads a state written by SaveState
+// Files of older versions are migrated to the current version.
+func LoadState(r io.Reader) (*State, error) {
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	var version int
+	if err := json.Unmarshal(doc["version"], &version); err != nil {
+		return nil, fmt.Errorf("save file has no valid version: %v", err)
+	}
+	if version < 1 || version > StateVersion() {
+		return nil, fmt.Errorf("unsupported save file version %d", version)
+	}
+	for ; version < StateVersion(); version++ {
+		if err := migrations[version-1](doc); err != nil {
+			return nil, fmt.Errorf("migrating save file from version %d: %v", version, err)
+		}
+	}
+	delete(doc, "version")
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveStateFile writes the state to the file at path
+// The state is written to a temporary file first, which replaces the file at path
+// once it is complete, so an interrupted save keeps the previous state.
+// The time the state was saved is updated.
+func SaveStateFile(path string, s *State) error {
+	s.Saved = time.Now()
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if err := SaveState(file, s); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// LoadStateFile reads the state from the file at path
+func LoadStateFile(path string) (*State, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadState(file)
+}
@@ -0,0 +1,244 @@
+package sudoku
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSaveState(t *testing.T) {
+	var steps []Step
+	solved, _ := SolveSteps(*testField, func(f Field, s Step) {
+		steps = append(steps, s)
+	})
+	partial := testField.Grid()
+	for _, s := range steps[:10] {
+		partial.Set(s.X, s.Y, s.Num)
+	}
+	// eliminate a candidate by hand
+	cands := partial.Candidates()
+	cands[1] = cands[1].Without(2)
+	state := NewState(testField.Grid(), partial, cands, steps[:10])
+	if state.Grid.Marks[1] != cands[1] {
+		t.Errorf("NewState() marks = %v, want %v", state.Grid.Marks[1], cands[1])
+	}
+
+	var b bytes.Buffer
+	if err := SaveState(&b, state); err != nil {
+		t.Errorf("SaveState() error = %v", err)
+		return
+	}
+	if !strings.Contains(b.String(), `"version": 2`) {
+		t.Errorf("SaveState() = %v, missing version", b.String())
+	}
+	got, err := LoadState(&b)
+	if err != nil {
+		t.Errorf("LoadState() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got, state) {
+		t.Errorf("LoadState() = %v, want %v", got, state)
+	}
+	if solved.EmptyCells() != 0 {
+		t.Errorf("SolveSteps() did not solve field")
+	}
+}
+
+func TestSaveState_Grid(t *testing.T) {
+	g, _ := ParseGrid("1..2.3.....1.4..")
+	g.Extra = Diagonals(4)
+	regions := []int{0, 0, 1, 1, 0, 0, 1, 1, 2, 2, 3, 3, 2, 2, 3, 3}
+	g.Regions = regions
+	// boxes of 2x3 cells are transposed to the boxes ParseGrid guesses for 6x6 grids
+	tall := NewGridBoxes(2, 3)
+	tall.Cells[0], tall.Cells[7] = 1, 2
+	killer := testField.Grid()
+	killer.Constraints = []Constraint{testKiller()}
+
+	tests := []struct {
+		name    string
+		g       *Grid
+		wantErr bool
+	}{
+		{name: "regions and extra houses", g: g},
+		{name: "6x6", g: tall},
+		{name: "jigsaw", g: jigsaw("9..5....8....5...4.....8.........9...17...4.....2..69......3..9....7.3...3..8....", testRegions)},
+		{name: "constraints", g: killer, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := tt.g.Clone()
+			current.Cells[len(current.Cells)-1] = EmptyCell
+			cands := current.Candidates()
+			// eliminate a candidate by hand
+			cands[len(cands)-1] = cands[len(cands)-1].Without(1)
+			state := NewState(tt.g, current, cands, nil)
+
+			var b bytes.Buffer
+			err := SaveState(&b, state)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SaveState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := LoadState(&b)
+			if err != nil {
+				t.Errorf("LoadState() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, state) {
+				t.Errorf("LoadState() = %#v, want %#v", got, state)
+			}
+		})
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *State
+		wantErr bool
+	}{
+		{
+			name:    "current version",
+			data:    `{"version":2,"boxes":[3,3],"givens":"` + testFieldLine + `","grid":"` + testFieldLine + `","steps":[]}`,
+			want:    &State{Givens: testField.Grid(), Grid: testField.Grid(), Steps: []Step{}},
+			wantErr: false,
+		},
+		{
+			name:    "missing version",
+			data:    `{"givens":"` + testFieldLine + `"}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "newer version",
+			data:    `{"version":1000}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid field",
+			data:    `{"version":1,"givens":"123"}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid boxes",
+			data:    `{"version":2,"boxes":[0,3],"givens":"` + testFieldLine + `","grid":"` + testFieldLine + `"}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "wrong number of candidates",
+			data:    `{"version":2,"boxes":[3,3],"givens":"` + testFieldLine + `","grid":"` + testFieldLine + `","candidates":[[1]]}`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadState(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadState_Version1(t *testing.T) {
+	// version 1 saved 9x9 fields with the candidates in 9 rows
+	rows := make([]string, 9)
+	for i := range rows {
+		cells := make([]string, 9)
+		for j := range cells {
+			cells[j] = "[]"
+		}
+		rows[i] = "[" + strings.Join(cells, ",") + "]"
+	}
+	rows[0] = "[[],[2,5],[],[],[],[],[],[],[]]"
+	old := `{"version":1,"givens":"` + testFieldLine + `","field":"` + testFieldLine + `",` +
+		`"candidates":[` + strings.Join(rows, ",") + `],"steps":[{"x":1,"y":0,"number":2,"strategy":"naked single"}]}`
+
+	got, err := LoadState(strings.NewReader(old))
+	if err != nil {
+		t.Errorf("LoadState() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Givens, testField.Grid()) || got.Grid.Line() != testFieldLine {
+		t.Errorf("LoadState() = %v, want %v", got, testField)
+	}
+	if len(got.Grid.Marks) != 81 || got.Grid.Marks[1] != Candidates(0).With(2).With(5) || got.Grid.Marks[9] != 0 {
+		t.Errorf("LoadState() marks = %v, want [2,5] in the second cell", got.Grid.Marks)
+	}
+	if len(got.Steps) != 1 || got.Steps[0].Num != 2 {
+		t.Errorf("LoadState() steps = %v", got.Steps)
+	}
+}
+
+func TestLoadState_Migration(t *testing.T) {
+	// pretend the givens were called "puzzle" in the previous version
+	defer func(m []func(map[string]json.RawMessage) error) { migrations = m }(migrations)
+	old := `{"version":` + strconv.Itoa(StateVersion()) + `,"boxes":[3,3],"puzzle":"` + testFieldLine + `","grid":"` + testFieldLine + `","steps":[]}`
+	migrations = append(migrations[:len(migrations):len(migrations)], func(doc map[string]json.RawMessage) error {
+		doc["givens"] = doc["puzzle"]
+		delete(doc, "puzzle")
+		return nil
+	})
+
+	got, err := LoadState(strings.NewReader(old))
+	if err != nil {
+		t.Errorf("LoadState() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Givens, testField.Grid()) {
+		t.Errorf("LoadState() givens = %v, want %v", got.Givens, testField)
+	}
+
+	var b bytes.Buffer
+	SaveState(&b, got)
+	if want := `"version": ` + strconv.Itoa(StateVersion()); !strings.Contains(b.String(), want) {
+		t.Errorf("SaveState() = %v, want %v", b.String(), want)
+	}
+}
+
+func TestSaveStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	state := NewState(testField.Grid(), testField.Grid(), nil, nil)
+	if err := SaveStateFile(path, state); err != nil {
+		t.Errorf("SaveStateFile() error = %v", err)
+		return
+	}
+	if state.Saved.IsZero() {
+		t.Errorf("SaveStateFile() did not set the save time")
+	}
+	got, err := LoadStateFile(path)
+	if err != nil {
+		t.Errorf("LoadStateFile() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Givens, state.Givens) || !got.Saved.Equal(state.Saved) {
+		t.Errorf("LoadStateFile() = %v, want %v", got, state)
+	}
+	// the previous state is replaced without leaving temporary files behind
+	if err := SaveStateFile(path, state); err != nil {
+		t.Errorf("SaveStateFile() error = %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*")); len(files) != 1 {
+		t.Errorf("SaveStateFile() left files %v", files)
+	}
+	if _, err := LoadStateFile(path + ".missing"); err == nil {
+		t.Errorf("LoadStateFile() error = nil for missing file")
+	}
+}
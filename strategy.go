@@ -0,0 +1,285 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Strategy is the rule used by the solver to set a cell
+type Strategy string
+
+const (
+	// HiddenSingleSquare means a number is only possible in one cell of a 3x3 square
+	HiddenSingleSquare = Strategy("hidden single in square")
+
+	// HiddenSingleRegion means a number is only possible in one cell of a jigsaw region
+	HiddenSingleRegion = Strategy("hidden single in region")
+
+	// HiddenSingleDiagonal means a number is only possible in one cell of a main diagonal
+	HiddenSingleDiagonal = Strategy("hidden single in diagonal")
+
+	// HiddenSingleWindow means a number is only possible in one cell of a window
+	HiddenSingleWindow = Strategy("hidden single in window")
+
+	// HiddenSingleRow means a number is only possible in one cell of a row
+	HiddenSingleRow = Strategy("hidden single in row")
+
+	// HiddenSingleColumn means a number is only possible in one cell of a column
+	HiddenSingleColumn = Strategy("hidden single in column")
+
+	// NakedSingle means only one number is possible in a cell
+	NakedSingle = Strategy("naked single")
+)
+
+// strategies lists all strategies from the easiest to the hardest
+var strategies = []Strategy{
+	HiddenSingleSquare,
+	HiddenSingleRegion,
+	HiddenSingleRow,
+	HiddenSingleColumn,
+	HiddenSingleDiagonal,
+	HiddenSingleWindow,
+	NakedSingle,
+}
+
+// hiddenSingles maps the type of a house to the strategy of a hidden single in it
+var hiddenSingles = map[ErrorType]Strategy{
+	Row:      HiddenSingleRow,
+	Column:   HiddenSingleColumn,
+	Square:   HiddenSingleSquare,
+	Region:   HiddenSingleRegion,
+	Diagonal: HiddenSingleDiagonal,
+	Window:   HiddenSingleWindow,
+}
+
+// hiddenSingle returns the strategy of a hidden single in a house of type t
+// Houses without a strategy of their own are named after their type.
+func hiddenSingle(t ErrorType) Strategy {
+	if s, ok := hiddenSingles[t]; ok {
+		return s
+	}
+	return Strategy("hidden single in " + string(t))
+}
+
+// Step is a single cell set by the solver
+type Step struct {
+	X        int      `json:"x"`
+	Y        int      `json:"y"`
+	Num      int      `json:"number"`
+	Strategy Strategy `json:"strategy"`
+}
+
+// Cells returns all cells of the row, column or square of a 9x9 field the step was found in
+// For naked singles only the set cell is returned, see Grid.StepCells.
+func (s Step) Cells() [][2]int {
+	return NewGridBoxes(3, 3).StepCells(s)
+}
+
+// StepCells returns all cells of the house the step was found in
+// For naked singles only the set cell is returned
+func (g *Grid) StepCells(s Step) [][2]int {
+	i := s.Y*g.Size + s.X
+	for _, h := range g.Houses() {
+		if s.Strategy == NakedSingle || hiddenSingle(h.Type) != s.Strategy {
+			continue
+		}
+		for _, c := range h.Cells {
+			if c != i {
+				continue
+			}
+			cells := make([][2]int, len(h.Cells))
+			for j, c := range h.Cells {
+				cells[j] = [2]int{c % g.Size, c / g.Size}
+			}
+			return cells
+		}
+	}
+	return [][2]int{{s.X, s.Y}}
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("(%d,%d): %d by %s", s.X, s.Y, s.Num, s.Strategy)
+}
+
+// Difficulty is the rating of a sudoku
+// It is the hardest strategy needed to solve it,
+// if the easiest possible strategy is used for every step
+type Difficulty int
+
+const (
+	// Easy sudokus only need hidden singles in squares
+	Easy Difficulty = iota + 1
+
+	// Medium sudokus need hidden singles in rows and columns
+	Medium
+
+	// Hard sudokus need naked singles
+	Hard
+
+	// Expert sudokus need more than the singles of this package, e.g. guessing
+	// The rating does not tell if they have a unique solution at all.
+	Expert
+)
+
+var difficultyNames = map[Difficulty]string{
+	Easy:   "easy",
+	Medium: "medium",
+	Hard:   "hard",
+	Expert: "expert",
+}
+
+// difficulty returns the difficulty of a sudoku which needs the strategy
+func (s Strategy) difficulty() Difficulty {
+	switch s {
+	case HiddenSingleSquare, HiddenSingleRegion:
+		return Easy
+	case HiddenSingleRow, HiddenSingleColumn, HiddenSingleDiagonal, HiddenSingleWindow:
+		return Medium
+	}
+	return Hard
+}
+
+func (d Difficulty) String() string {
+	if name, ok := difficultyNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("Difficulty(%d)", int(d))
+}
+
+// MarshalText turns the difficulty into its name
+func (d Difficulty) MarshalText() ([]byte, error) {
+	if _, ok := difficultyNames[d]; !ok {
+		return nil, fmt.Errorf("unknown difficulty %d", int(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads the difficulty from its name
+func (d *Difficulty) UnmarshalText(text []byte) error {
+	for k, name := range difficultyNames {
+		if name == string(text) {
+			*d = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown difficulty '%s'", text)
+}
+
+// Hint returns the next cell to set, found with the easiest possible strategy
+func Hint(f Field) (*Step, error) {
+	return HintGrid(*f.Grid())
+}
+
+// HintGrid returns the next cell of a grid of any size to set like Hint
+func HintGrid(g Grid) (*Step, error) {
+	if err := g.Check(); err != nil {
+		return nil, fmt.Errorf("field is invalid: %w", err)
+	}
+	if g.EmptyCells() == 0 {
+		return nil, errors.New("field is already solved")
+	}
+	step, ok := g.nextStep(g.Houses(), g.Candidates())
+	if !ok {
+		return nil, ErrStuck
+	}
+	return &step, nil
+}
+
+// Rate calculates the difficulty of a sudoku
+func Rate(f Field) (Difficulty, error) {
+	return RateGrid(*f.Grid())
+}
+
+// RateGrid calculates the difficulty of a grid of any size like Rate
+func RateGrid(g Grid) (Difficulty, error) {
+	return RateGridContext(context.Background(), g)
+}
+
+// RateGridContext rates a grid like RateGrid, but stops with the error of the context once it is done
+func RateGridContext(ctx context.Context, g Grid) (Difficulty, error) {
+	if err := g.Check(); err != nil {
+		return 0, fmt.Errorf("field is invalid: %w", err)
+	}
+	rating, err := g.Clone().rate(ctx, nil)
+	if err == ErrStuck {
+		return rating, nil
+	}
+	return rating, err
+}
+
+// rate sets the cells of the grid with the easiest possible strategy until it is solved
+// onStep is called for every set cell. If the strategies are not enough,
+// Expert and ErrStuck are returned.
+func (g *Grid) rate(ctx context.Context, onStep func(s Step)) (Difficulty, error) {
+	houses := g.Houses()
+	m := g.Model()
+	solvable := m.Propagate()
+	rating := Easy
+	for g.EmptyCells() > 0 {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		step, ok := g.nextStep(houses, m.Domains)
+		if !solvable || !ok {
+			return Expert, ErrStuck
+		}
+		if d := step.Strategy.difficulty(); d > rating {
+			rating = d
+		}
+		g.Set(step.X, step.Y, step.Num)
+		solvable = m.Set(step.Y*g.Size+step.X, step.Num)
+		if onStep != nil {
+			onStep(step)
+		}
+	}
+	return rating, nil
+}
+
+// nextStep finds a cell to set with the easiest possible strategy
+// Hidden singles in houses without a strategy of their own are tried before naked singles.
+func (g *Grid) nextStep(houses []House, cands []Candidates) (Step, bool) {
+	for _, s := range strategies {
+		if s == NakedSingle {
+			break
+		}
+		if step, ok := g.findHiddenSingle(houses, cands, func(t ErrorType) bool { return hiddenSingles[t] == s }); ok {
+			return step, true
+		}
+	}
+	if step, ok := g.findHiddenSingle(houses, cands, func(t ErrorType) bool { return hiddenSingles[t] == "" }); ok {
+		return step, true
+	}
+	for i := range g.Cells {
+		if num, ok := cands[i].Single(); ok {
+			return Step{X: i % g.Size, Y: i / g.Size, Num: num, Strategy: NakedSingle}, true
+		}
+	}
+	return Step{}, false
+}
+
+// findHiddenSingle finds the first number which is only possible in one cell of a house
+// Only the houses whose type is matched are searched.
+func (g *Grid) findHiddenSingle(houses []House, cands []Candidates, match func(t ErrorType) bool) (Step, bool) {
+	for _, h := range houses {
+		if !match(h.Type) {
+			continue
+		}
+	num:
+		for n := 1; n <= g.Size; n++ {
+			pos := -1
+			for _, i := range h.Cells {
+				if g.Cells[i] == EmptyCell && cands[i].Has(n) {
+					if pos != -1 {
+						continue num
+					}
+					pos = i
+				}
+			}
+			if pos != -1 {
+				return Step{X: pos % g.Size, Y: pos / g.Size, Num: n, Strategy: hiddenSingle(h.Type)}, true
+			}
+		}
+	}
+	return Step{}, false
+}
@@ -0,0 +1,243 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHint(t *testing.T) {
+	// generate field with error
+	errorfield := *testField
+	errorfield[0][1] = 7
+
+	tests := []struct {
+		name    string
+		f       Field
+		want    *Step
+		wantErr bool
+	}{
+		{
+			name:    "easiest step",
+			f:       *testField,
+			want:    &Step{X: 1, Y: 2, Num: 4, Strategy: HiddenSingleSquare},
+			wantErr: false,
+		},
+		{
+			name:    "solved field",
+			f:       *testFieldSolved,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "field with errors",
+			f:       errorfield,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty field",
+			f:       Field{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Hint(tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Hint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Hint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	// field where every missing number is the only one in its square
+	easy := *testFieldSolved
+	easy[0][0], easy[4][4], easy[8][8] = 0, 0, 0
+
+	tests := []struct {
+		name    string
+		f       Field
+		want    Difficulty
+		wantErr bool
+	}{
+		{
+			name:    "solved field",
+			f:       *testFieldSolved,
+			want:    Easy,
+			wantErr: false,
+		},
+		{
+			name:    "easy field",
+			f:       easy,
+			want:    Easy,
+			wantErr: false,
+		},
+		{
+			name:    "test field",
+			f:       *testField,
+			want:    Medium,
+			wantErr: false,
+		},
+		{
+			name:    "no clear solution",
+			f:       *testField2,
+			want:    Expert,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Rate(tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Rate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDifficulty_UnmarshalText(t *testing.T) {
+	for d := Easy; d <= Expert; d++ {
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Errorf("Difficulty.MarshalText() error = %v", err)
+			continue
+		}
+		var got Difficulty
+		if err := got.UnmarshalText(text); err != nil || got != d {
+			t.Errorf("Difficulty.UnmarshalText(%s) = %v, %v, want %v", text, got, err, d)
+		}
+	}
+	var d Difficulty
+	if err := d.UnmarshalText([]byte("impossible")); err == nil {
+		t.Errorf("Difficulty.UnmarshalText() error = nil for unknown name")
+	}
+}
+
+func TestHintGrid(t *testing.T) {
+	tests := []struct {
+		name    string
+		g       *Grid
+		want    *Step
+		wantErr bool
+	}{
+		{
+			name: "6x6 grid",
+			g:    removeCells(patternGrid(3, 2), 4),
+			want: &Step{X: 0, Y: 0, Num: 1, Strategy: HiddenSingleSquare},
+		},
+		{
+			name: "jigsaw",
+			g:    jigsaw("9..5....8....5...4.....8.........9...17...4.....2..69......3..9....7.3...3..8....", testRegions),
+			want: &Step{X: 2, Y: 2, Num: 9, Strategy: HiddenSingleRegion},
+		},
+		{
+			name:    "solved grid",
+			g:       patternGrid(2, 2),
+			wantErr: true,
+		},
+		{
+			name:    "empty grid",
+			g:       NewGridBoxes(2, 2),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HintGrid(*tt.g)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HintGrid() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HintGrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateGrid(t *testing.T) {
+	invalid := patternGrid(2, 2)
+	invalid.Cells[1] = invalid.Cells[0]
+
+	tests := []struct {
+		name    string
+		g       *Grid
+		want    Difficulty
+		wantErr bool
+	}{
+		{
+			name: "16x16 grid",
+			g:    removeCells(patternGrid(4, 4), 3),
+			want: Easy,
+		},
+		{
+			name: "jigsaw",
+			g:    jigsaw("9..5....8....5...4.....8.........9...17...4.....2..69......3..9....7.3...3..8....", testRegions),
+			want: Medium,
+		},
+		{
+			name: "empty grid",
+			g:    NewGridBoxes(3, 2),
+			want: Expert,
+		},
+		{
+			name:    "grid with errors",
+			g:       invalid,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RateGrid(*tt.g)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RateGrid() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RateGrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid_StepCells(t *testing.T) {
+	g := NewGridBoxes(3, 2)
+	tests := []struct {
+		name string
+		s    Step
+		want [][2]int
+	}{
+		{
+			name: "box",
+			s:    Step{X: 4, Y: 3, Num: 1, Strategy: HiddenSingleSquare},
+			want: [][2]int{{3, 2}, {4, 2}, {5, 2}, {3, 3}, {4, 3}, {5, 3}},
+		},
+		{
+			name: "column",
+			s:    Step{X: 1, Y: 0, Num: 1, Strategy: HiddenSingleColumn},
+			want: [][2]int{{1, 0}, {1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}},
+		},
+		{
+			name: "naked single",
+			s:    Step{X: 2, Y: 5, Num: 1, Strategy: NakedSingle},
+			want: [][2]int{{2, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.StepCells(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Grid.StepCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package sudoku
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// svgStyle is embedded into every SVG and can be overwritten by a surrounding page
+// solved numbers use the same green as PrettyPrint
+const svgStyle = `line{stroke:#000;stroke-linecap:square}` +
+	`.thin{stroke-width:1}.thick{stroke-width:3}` +
+	`text{font-family:sans-serif;text-anchor:middle;dominant-baseline:central}` +
+	`.given{fill:#000}.solved{fill:#1a9f1a}.conflict{fill:#d02020}.candidate{fill:#666}` +
+	`.highlight{fill:#fff0a0}`
+
+// RenderSVG writes the field as SVG image
+// See Grid.RenderSVG
+func (f Field) RenderSVG(w io.Writer, opts RenderOptions) error {
+	return f.Grid().RenderSVG(w, opts)
+}
+
+// RenderSVG writes the grid as SVG image
+// Thick lines separate the boxes or regions,
+// set cells which are not part of opts.Initial are drawn in green
+// and numbers which violate the sudoku rules in red
+func (g *Grid) RenderSVG(w io.Writer, opts RenderOptions) error {
+	b := bufio.NewWriter(w)
+	g.writeSVG(b, opts, "")
+	return b.Flush()
+}
+
+// svgGap is the space between two grids in RenderSVGs
+const svgGap = 20
+
+// RenderSVGs writes several grids below each other as a single SVG image
+// Every grid is a nested image like Grid.RenderSVG with the options of the same index.
+func RenderSVGs(w io.Writer, grids []*Grid, opts []RenderOptions) error {
+	if len(grids) != len(opts) {
+		return fmt.Errorf("Got %d grids, but options for %d", len(grids), len(opts))
+	}
+	width, height := 0, 0
+	for i, g := range grids {
+		size := g.svgSize(opts[i])
+		if size > width {
+			width = size
+		}
+		if i > 0 {
+			height += svgGap
+		}
+		height += size
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	y := 0
+	for i, g := range grids {
+		g.writeSVG(b, opts[i], fmt.Sprintf(` y="%d"`, y))
+		y += g.svgSize(opts[i]) + svgGap
+	}
+	b.WriteString("</svg>\n")
+	return b.Flush()
+}
+
+// svgMargin leaves space for the thick outer border
+const svgMargin = 2
+
+// svgSize returns the width and height of the SVG image of the grid
+func (g *Grid) svgSize(opts RenderOptions) int {
+	return g.Size*opts.cellSize() + 2*svgMargin
+}
+
+// writeSVG writes the SVG image of the grid, attrs are added to its root element
+// e.g. the position of a nested image: ` y="100"`
+func (g *Grid) writeSVG(b *bufio.Writer, opts RenderOptions, attrs string) {
+	n := g.Size
+	cell := opts.cellSize()
+	margin := svgMargin
+	size := g.svgSize(opts)
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg"%s width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", attrs, size, size, size, size)
+	fmt.Fprintf(b, "<style>%s</style>\n", svgStyle)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#fff"/>`+"\n", size, size)
+	if opts.Step != nil {
+		for _, c := range g.StepCells(*opts.Step) {
+			fmt.Fprintf(b, `<rect class="highlight" x="%d" y="%d" width="%d" height="%d"/>`+"\n", margin+c[0]*cell, margin+c[1]*cell, cell, cell)
+		}
+	}
+
+	// grid lines, thick lines are drawn last so they cover the thin ones
+	for i := 1; i < n; i++ {
+		p := margin + i*cell
+		fmt.Fprintf(b, `<line class="thin" x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", p, margin, p, size-margin)
+		fmt.Fprintf(b, `<line class="thin" x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", margin, p, size-margin, p)
+	}
+	for _, l := range g.thickLines() {
+		fmt.Fprintf(b, `<line class="thick" x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n",
+			margin+l[0]*cell, margin+l[1]*cell, margin+l[2]*cell, margin+l[3]*cell)
+	}
+
+	conflicts := g.Conflicts()
+	cols, rows := g.candidateLayout()
+	sub := cols
+	if rows > sub {
+		sub = rows
+	}
+	for i, num := range g.Cells {
+		x, y := margin+(i%n)*cell, margin+(i/n)*cell
+		if num != EmptyCell {
+			class := "given"
+			switch {
+			case conflicts[i]:
+				class = "conflict"
+			case opts.solved(g, i):
+				class = "solved"
+			}
+			fmt.Fprintf(b, `<text class="%s" x="%d" y="%d" font-size="%d">%s</text>`+"\n", class, x+cell/2, y+cell/2, cell*2/3, digit(num))
+			continue
+		}
+		if opts.Candidates == nil {
+			continue
+		}
+		for c := 1; c <= n; c++ {
+			if !opts.Candidates[i].Has(c) {
+				continue
+			}
+			cx := x + (2*((c-1)%cols)+1)*cell/(2*cols)
+			cy := y + (2*((c-1)/cols)+1)*cell/(2*rows)
+			fmt.Fprintf(b, `<text class="candidate" x="%d" y="%d" font-size="%d">%s</text>`+"\n", cx, cy, cell*3/(4*sub), digit(c))
+		}
+	}
+	b.WriteString("</svg>\n")
+}
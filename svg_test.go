@@ -0,0 +1,185 @@
+package sudoku
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestField_RenderSVG(t *testing.T) {
+	candidates := make([]Candidates, 9*9)
+	candidates[1] = Candidates(0).With(2).With(5).With(6)
+	tests := []struct {
+		name       string
+		f          Field
+		opts       RenderOptions
+		given      int
+		solved     int
+		candidates int
+		contains   string
+	}{
+		{
+			name:     "empty field",
+			f:        Field{},
+			opts:     RenderOptions{},
+			contains: `width="364"`,
+		},
+		{
+			name:     "givens",
+			f:        *testField,
+			opts:     RenderOptions{CellSize: 20},
+			given:    23,
+			contains: `width="184"`,
+		},
+		{
+			name:     "solved field",
+			f:        *testFieldSolved,
+			opts:     RenderOptions{Initial: testField.Grid()},
+			given:    23,
+			solved:   9*9 - 23,
+			contains: `<text class="solved" x="62" y="22" font-size="26">2</text>`,
+		},
+		{
+			name:       "candidates",
+			f:          *testField,
+			opts:       RenderOptions{Candidates: candidates},
+			given:      23,
+			candidates: 3,
+			contains:   `<text class="candidate" x="62" y="8" font-size="10">2</text>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.f.RenderSVG(&b, tt.opts); err != nil {
+				t.Errorf("Field.RenderSVG() error = %v", err)
+				return
+			}
+			got := b.String()
+
+			// count the classes of all texts in the valid XML document
+			count := map[string]int{}
+			dec := xml.NewDecoder(strings.NewReader(got))
+			for {
+				tok, err := dec.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Errorf("Field.RenderSVG() returned invalid XML: %v", err)
+					return
+				}
+				if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "text" {
+					for _, attr := range start.Attr {
+						if attr.Name.Local == "class" {
+							count[attr.Value]++
+						}
+					}
+				}
+			}
+			if count["given"] != tt.given || count["solved"] != tt.solved || count["candidate"] != tt.candidates {
+				t.Errorf("Field.RenderSVG() has %v, want %d given, %d solved and %d candidates", count, tt.given, tt.solved, tt.candidates)
+			}
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("Field.RenderSVG() = %v, does not contain %v", got, tt.contains)
+			}
+		})
+	}
+}
+
+func TestGrid_RenderSVG(t *testing.T) {
+	tests := []struct {
+		name     string
+		g        *Grid
+		opts     RenderOptions
+		thick    int
+		contains string
+	}{
+		{
+			name:     "6x6 grid",
+			g:        patternGrid(3, 2),
+			thick:    3 + 4,
+			contains: `<line class="thick" x1="2" y1="82" x2="242" y2="82"/>`,
+		},
+		{
+			name:     "16x16 grid",
+			g:        patternGrid(4, 4),
+			opts:     RenderOptions{CellSize: 20},
+			thick:    5 + 5,
+			contains: `<text class="given" x="12" y="12" font-size="13">1</text>`,
+		},
+		{
+			name:     "letters",
+			g:        patternGrid(4, 4),
+			thick:    5 + 5,
+			contains: `>G</text>`,
+		},
+		{
+			name: "jigsaw",
+			g:    jigsaw(strings.Repeat(".", 16), "AABBACBBACDDCCDD"),
+			// four borders around the grid and five between the regions
+			thick:    4 + 5,
+			contains: `<line class="thick" x1="42" y1="42" x2="42" y2="122"/>`,
+		},
+		{
+			name:     "candidates in box layout",
+			g:        NewGridBoxes(3, 2),
+			opts:     RenderOptions{Candidates: NewGridBoxes(3, 2).Candidates()},
+			contains: `<text class="candidate" x="8" y="32" font-size="10">4</text>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.g.RenderSVG(&b, tt.opts); err != nil {
+				t.Errorf("Grid.RenderSVG() error = %v", err)
+				return
+			}
+			got := b.String()
+			if tt.thick != 0 {
+				if n := strings.Count(got, `class="thick"`); n != tt.thick {
+					t.Errorf("Grid.RenderSVG() has %d thick lines, want %d", n, tt.thick)
+				}
+			}
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("Grid.RenderSVG() = %v, does not contain %v", got, tt.contains)
+			}
+		})
+	}
+}
+
+func TestRenderSVGs(t *testing.T) {
+	grids := []*Grid{testField.Grid(), patternGrid(3, 2)}
+	opts := []RenderOptions{{}, {CellSize: 20}}
+	var b bytes.Buffer
+	if err := RenderSVGs(&b, grids, opts); err != nil {
+		t.Fatalf("RenderSVGs() error = %v", err)
+	}
+	var doc struct {
+		Width  int `xml:"width,attr"`
+		Height int `xml:"height,attr"`
+		Grids  []struct {
+			Y     int `xml:"y,attr"`
+			Width int `xml:"width,attr"`
+		} `xml:"svg"`
+	}
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("RenderSVGs() is no valid XML: %v", err)
+	}
+	if n := strings.Count(b.String(), "</svg>"); n != 3 {
+		t.Errorf("RenderSVGs() has %d images, want a root with 2 nested images", n)
+	}
+	// 9*40+4 and 6*20+4 pixels with a gap of 20
+	if doc.Width != 364 || doc.Height != 364+20+124 {
+		t.Errorf("RenderSVGs() size = %dx%d, want %dx%d", doc.Width, doc.Height, 364, 364+20+124)
+	}
+	if len(doc.Grids) != 2 || doc.Grids[1].Y != 384 || doc.Grids[1].Width != 124 {
+		t.Errorf("RenderSVGs() nested images = %+v", doc.Grids)
+	}
+
+	if err := RenderSVGs(&b, grids, opts[:1]); err == nil {
+		t.Errorf("RenderSVGs() error = nil for missing options")
+	}
+}
@@ -0,0 +1,39 @@
+package sudoku
+
+// Thermometer is a path of cells whose numbers strictly increase from the bulb
+type Thermometer struct {
+	// Cells are the indices of the cells in Grid.Cells starting at the bulb
+	Cells []int
+}
+
+// Check validates that the numbers increase along the thermometer
+// Two set cells must be far enough apart for the numbers between them.
+func (t *Thermometer) Check(g *Grid) error {
+	for j, a := range t.Cells {
+		for k := j + 1; k < len(t.Cells); k++ {
+			b := t.Cells[k]
+			if g.Cells[a] == EmptyCell || g.Cells[b] == EmptyCell {
+				continue
+			}
+			if g.Cells[b]-g.Cells[a] < k-j {
+				return newErrorConstraint(g, ThermoOrder, []int{a, b}, "%d and %d cannot increase along %d cells",
+					g.Cells[a], g.Cells[b], k-j+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Propagators returns a relation between every two neighboring cells of the thermometer
+func (t *Thermometer) Propagators(g *Grid) []Propagator {
+	var props []Propagator
+	for k := 1; k < len(t.Cells); k++ {
+		props = append(props, &Relation{A: t.Cells[k-1], B: t.Cells[k], Holds: less})
+	}
+	return props
+}
+
+// less holds if a is smaller than b
+func less(a, b int) bool {
+	return a < b
+}
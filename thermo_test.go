@@ -0,0 +1,77 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestThermometer_Check(t *testing.T) {
+	thermo := &Thermometer{Cells: []int{0, 1, 2, 11}}
+	tests := []struct {
+		name      string
+		cells     map[int]int
+		wantCells [][2]int
+	}{
+		{name: "empty"},
+		{name: "increasing", cells: map[int]int{0: 2, 1: 3, 11: 8}},
+		{name: "gap for missing numbers", cells: map[int]int{0: 2, 11: 5}},
+		{name: "equal", cells: map[int]int{0: 2, 1: 2}, wantCells: [][2]int{{0, 0}, {1, 0}}},
+		{name: "decreasing", cells: map[int]int{1: 5, 2: 4}, wantCells: [][2]int{{1, 0}, {2, 0}}},
+		{name: "no gap for missing numbers", cells: map[int]int{1: 5, 11: 6}, wantCells: [][2]int{{1, 0}, {2, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGridBoxes(3, 3)
+			for i, num := range tt.cells {
+				g.Cells[i] = num
+			}
+			err := thermo.Check(g)
+			if (err != nil) != (tt.wantCells != nil) {
+				t.Errorf("Thermometer.Check() error = %v, want cells %v", err, tt.wantCells)
+				return
+			}
+			var errConstraint ErrorConstraint
+			if err != nil && (!errors.As(err, &errConstraint) || errConstraint.Type() != ThermoOrder ||
+				!reflect.DeepEqual(errConstraint.Cells(), tt.wantCells)) {
+				t.Errorf("Thermometer.Check() error = %v, want cells %v", err, tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestThermometer_Propagators(t *testing.T) {
+	g := NewGridBoxes(3, 3)
+	g.Cells[2] = 5
+	cands := make([]Candidates, len(g.Cells))
+	for i := range cands {
+		if g.Cells[i] == EmptyCell {
+			cands[i] = AllCandidates(9)
+		}
+	}
+	thermo := &Thermometer{Cells: []int{0, 1, 2, 3, 4}}
+	if !propagateConstraint(g, thermo, cands) {
+		t.Fatalf("Thermometer.Propagators() failed")
+	}
+	want := []Candidates{between(1, 3), between(2, 4), 0, between(6, 8), between(7, 9)}
+	if !reflect.DeepEqual(cands[:5], want) {
+		t.Errorf("Thermometer.Propagators() pruned %v, want %v", cands[:5], want)
+	}
+}
+
+func TestSolveGrid_Thermometer(t *testing.T) {
+	g, _ := ParseGrid("7......1...3.........82..5.4..53..9....4....63.....5..........1.........576.4...8")
+	for _, cells := range [][]int{
+		{42, 43, 44}, {54, 55, 56}, {39, 48, 57}, {22, 31, 40}, {49, 58, 67},
+		{41, 50, 59}, {42, 51, 60}, {17, 26, 35}, {62, 71, 80},
+	} {
+		g.Constraints = append(g.Constraints, &Thermometer{Cells: cells})
+	}
+	got, err := SolveGrid(*g, nil)
+	if err != nil {
+		t.Fatalf("SolveGrid() error = %v", err)
+	}
+	if want := testFieldSolved.Grid(); got.Line() != want.Line() {
+		t.Errorf("SolveGrid() = %s, want %s", got.Line(), want.Line())
+	}
+}